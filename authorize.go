@@ -0,0 +1,164 @@
+package golack
+
+import (
+	"context"
+	"errors"
+	"github.com/oklahomer/golack/v2/event"
+	"github.com/oklahomer/golack/v2/eventsapi"
+	"github.com/oklahomer/golack/v2/oauth"
+	"github.com/oklahomer/golack/v2/webapi"
+	"sync"
+	"time"
+)
+
+// ErrInstallationStoreNotSet is returned when a token is resolved without WithInstallationStore.
+var ErrInstallationStoreNotSet = errors.New("installation store is not set")
+
+// WithInstallationStore provides a way to resolve the token of each workspace for an app that is distributed to multiple workspaces.
+// Pass the returned Option to New(), and then call ContextForEvent or ContextForTeam to build a context for Web API calls.
+// When Config.ClientID and Config.ClientSecret are set, the rotating tokens are refreshed and saved back to the store.
+// The refresh request is sent with WebClient when it is *webapi.Client, or with a Client built from Config otherwise.
+func WithInstallationStore(store oauth.InstallationStore) Option {
+	return func(g *Golack) {
+		g.resolver = &tokenResolver{
+			store:     store,
+			providers: map[string]*cachedProvider{},
+			mutex:     &sync.Mutex{},
+		}
+	}
+}
+
+// ContextForEvent returns a copy of the given context that carries the token of the installation that received the given event.
+// Web API calls made by this Golack with the returned context use the token instead of Config.Token.
+//
+//	receiver := eventsapi.NewDefaultEventReceiver(func(wrapper *eventsapi.EventWrapper) {
+//	    ctx, err := g.ContextForEvent(context.Background(), wrapper)
+//	    if err != nil {
+//	        return
+//	    }
+//	    g.PostMessage(ctx, webapi.NewPostMessage(channelID, "Hello"))
+//	})
+//
+// The workspace is identified by the authorizations field, or by team_id and enterprise_id when it is not given.
+// The bot token is used whenever the installation has one, even when the event is delivered on behalf of the installing user,
+// so the app does not act as the user unexpectedly. The user token is used only for a user-token-only installation.
+func (g *Golack) ContextForEvent(ctx context.Context, wrapper *eventsapi.EventWrapper) (context.Context, error) {
+	enterpriseID := wrapper.EnterpriseID
+	teamID := event.TeamID(wrapper.TeamID)
+	if len(wrapper.Authorizations) > 0 {
+		authorization := wrapper.Authorizations[0]
+		enterpriseID = authorization.EnterpriseID
+		teamID = authorization.TeamID
+	}
+
+	installation, err := g.findInstallation(ctx, enterpriseID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.resolver.contextWithToken(ctx, g.config, installation, installation.BotToken == ""), nil
+}
+
+// ContextForTeam returns a copy of the given context that carries the bot token of the given workspace.
+// This is useful to call Web API outside of event handling such as on slash command and scheduled tasks.
+// Give an empty enterpriseID for a workspace that does not belong to an Enterprise Grid organization.
+func (g *Golack) ContextForTeam(ctx context.Context, enterpriseID string, teamID event.TeamID) (context.Context, error) {
+	installation, err := g.findInstallation(ctx, enterpriseID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.resolver.contextWithToken(ctx, g.config, installation, installation.BotToken == ""), nil
+}
+
+func (g *Golack) findInstallation(ctx context.Context, enterpriseID string, teamID event.TeamID) (*oauth.Installation, error) {
+	if g.resolver == nil {
+		return nil, ErrInstallationStoreNotSet
+	}
+
+	return g.resolver.store.Find(ctx, enterpriseID, teamID)
+}
+
+type cachedProvider struct {
+	expiresAt time.Time
+	provider  *webapi.RotatingTokenProvider
+}
+
+// tokenResolver builds the context that carries the token of each installation.
+// Rotating tokens share one provider per installation so concurrent events do not refresh the same token at once.
+type tokenResolver struct {
+	store         oauth.InstallationStore
+	providers     map[string]*cachedProvider
+	refreshClient *webapi.Client
+	mutex         *sync.Mutex
+}
+
+func (r *tokenResolver) contextWithToken(ctx context.Context, config *Config, installation *oauth.Installation, user bool) context.Context {
+	token := &webapi.RotatingToken{
+		AccessToken:  installation.BotToken,
+		RefreshToken: installation.BotRefreshToken,
+		ExpiresAt:    installation.BotTokenExpiresAt,
+	}
+	if user {
+		token = &webapi.RotatingToken{
+			AccessToken:  installation.UserToken,
+			RefreshToken: installation.UserRefreshToken,
+			ExpiresAt:    installation.UserTokenExpiresAt,
+		}
+	}
+
+	if token.RefreshToken == "" || config.ClientID == "" || config.ClientSecret == "" {
+		return webapi.ContextWithToken(ctx, token.AccessToken)
+	}
+
+	// The installation is identified as InstallationStore does, so an organization-wide installation is shared among its workspaces
+	teamID := installation.TeamID
+	if installation.IsEnterpriseInstall {
+		teamID = ""
+	}
+	key := installation.EnterpriseID + ":" + teamID.String()
+	if user {
+		key += ":" + installation.UserID.String()
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// A provider is replaced when the saved token is newer than the provider's, which means the app is re-installed.
+	// An older one is ignored since the saved token may lag behind the one that the provider has just refreshed.
+	cached, ok := r.providers[key]
+	if !ok || token.ExpiresAt.After(cached.expiresAt) {
+		cached = &cachedProvider{expiresAt: token.ExpiresAt}
+		cached.provider = webapi.NewRotatingTokenProvider(config.ClientID, config.ClientSecret, token,
+			webapi.WithRefreshClient(r.refreshClient),
+			webapi.WithTokenPersister(r.persister(installation.EnterpriseID, teamID, user, cached)))
+		r.providers[key] = cached
+	}
+
+	return webapi.ContextWithTokenProvider(ctx, cached.provider)
+}
+
+// persister returns a function that saves the refreshed token back to the store.
+func (r *tokenResolver) persister(enterpriseID string, teamID event.TeamID, user bool, cached *cachedProvider) func(context.Context, *webapi.RotatingToken) error {
+	return func(ctx context.Context, token *webapi.RotatingToken) error {
+		r.mutex.Lock()
+		cached.expiresAt = token.ExpiresAt
+		r.mutex.Unlock()
+
+		installation, err := r.store.Find(ctx, enterpriseID, teamID)
+		if err != nil {
+			return err
+		}
+
+		if user {
+			installation.UserToken = token.AccessToken
+			installation.UserRefreshToken = token.RefreshToken
+			installation.UserTokenExpiresAt = token.ExpiresAt
+		} else {
+			installation.BotToken = token.AccessToken
+			installation.BotRefreshToken = token.RefreshToken
+			installation.BotTokenExpiresAt = token.ExpiresAt
+		}
+		return r.store.Save(ctx, installation)
+	}
+}
@@ -0,0 +1,312 @@
+package golack
+
+import (
+	"context"
+	"errors"
+	"github.com/oklahomer/golack/v2/eventsapi"
+	"github.com/oklahomer/golack/v2/oauth"
+	"github.com/oklahomer/golack/v2/webapi"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// tokenRecorder serves any Web API method and records the given Authorization header.
+type tokenRecorder struct {
+	given string
+}
+
+func (r *tokenRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.given = req.Header.Get("Authorization")
+	w := httptest.NewRecorder()
+	w.Write([]byte(`{"ok": true}`))
+	return w.Result(), nil
+}
+
+func decodeEventWrapper(t *testing.T, payload string) *eventsapi.EventWrapper {
+	decoded, err := eventsapi.DecodePayload(&eventsapi.SlackRequest{Payload: []byte(payload)})
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+	return decoded.(*eventsapi.EventWrapper)
+}
+
+func newMultiTeamGolack(t *testing.T, recorder *tokenRecorder) *Golack {
+	store := oauth.NewMemoryInstallationStore()
+	installations := []*oauth.Installation{
+		{
+			TeamID:    "T123",
+			BotToken:  "xoxb-123",
+			UserID:    "U123",
+			UserToken: "xoxp-123",
+		},
+		{
+			TeamID:    "T456",
+			UserID:    "U456",
+			UserToken: "xoxp-456",
+		},
+		{
+			EnterpriseID:        "E123",
+			IsEnterpriseInstall: true,
+			BotToken:            "xoxb-org",
+		},
+	}
+	for _, installation := range installations {
+		err := store.Save(context.TODO(), installation)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+	}
+
+	apiConfig := webapi.NewConfig()
+	apiConfig.Token = "xoxb-default"
+	webClient := webapi.NewClient(apiConfig, webapi.WithHTTPClient(&http.Client{Transport: recorder}))
+	return New(NewConfig(), WithWebClient(webClient), WithInstallationStore(store))
+}
+
+func TestWithInstallationStore(t *testing.T) {
+	store := oauth.NewMemoryInstallationStore()
+	g := &Golack{}
+	WithInstallationStore(store)(g)
+
+	if g.resolver == nil || g.resolver.store != store {
+		t.Errorf("Given store is not set: %#v.", g.resolver)
+	}
+}
+
+func TestGolack_ContextForEvent(t *testing.T) {
+	tests := []struct {
+		payload  string
+		expected string
+		err      error
+	}{
+		{
+			payload:  `{"type": "event_callback", "team_id": "T123", "event": {"type": "app_mention"}}`,
+			expected: "Bearer xoxb-123",
+		},
+		{
+			payload: `{"type": "event_callback", "team_id": "T999", "event": {"type": "app_mention"},
+				"authorizations": [{"team_id": "T123", "user_id": "U999", "is_bot": true}]}`,
+			expected: "Bearer xoxb-123",
+		},
+		{
+			// Delivered on behalf of the user who installed the app, but the bot token is still used
+			payload: `{"type": "event_callback", "team_id": "T123", "event": {"type": "message"},
+				"authorizations": [{"team_id": "T123", "user_id": "U123", "is_bot": false}]}`,
+			expected: "Bearer xoxb-123",
+		},
+		{
+			// User-token-only installation
+			payload:  `{"type": "event_callback", "team_id": "T456", "event": {"type": "message"}}`,
+			expected: "Bearer xoxp-456",
+		},
+		{
+			// Organization-wide installation
+			payload: `{"type": "event_callback", "team_id": "T789", "enterprise_id": "E123", "event": {"type": "app_mention"},
+				"authorizations": [{"enterprise_id": "E123", "team_id": "T789", "user_id": "U999", "is_bot": true, "is_enterprise_install": true}]}`,
+			expected: "Bearer xoxb-org",
+		},
+		{
+			payload: `{"type": "event_callback", "team_id": "T999", "event": {"type": "app_mention"}}`,
+			err:     oauth.ErrInstallationNotFound,
+		},
+	}
+
+	for i, tt := range tests {
+		recorder := &tokenRecorder{}
+		g := newMultiTeamGolack(t, recorder)
+
+		ctx, err := g.ContextForEvent(context.TODO(), decodeEventWrapper(t, tt.payload))
+		if tt.err != nil {
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Expected error is not returned on test #%d: %#v.", i, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error is returned on test #%d: %s.", i, err.Error())
+		}
+
+		_, err = g.PostMessage(ctx, webapi.NewPostMessage("C123", "Hello"))
+		if err != nil {
+			t.Fatalf("Unexpected error is returned on test #%d: %s.", i, err.Error())
+		}
+
+		if recorder.given != tt.expected {
+			t.Errorf("Unexpected token is sent on test #%d: %s.", i, recorder.given)
+		}
+	}
+}
+
+func TestGolack_ContextForTeam(t *testing.T) {
+	recorder := &tokenRecorder{}
+	g := newMultiTeamGolack(t, recorder)
+
+	ctx, err := g.ContextForTeam(context.TODO(), "", "T123")
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+
+	_, err = g.PostMessage(ctx, webapi.NewPostMessage("C123", "Hello"))
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+	if recorder.given != "Bearer xoxb-123" {
+		t.Errorf("Unexpected token is sent: %s.", recorder.given)
+	}
+
+	// Config.Token is used without the token in the context
+	_, err = g.PostMessage(context.TODO(), webapi.NewPostMessage("C123", "Hello"))
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+	if recorder.given != "Bearer xoxb-default" {
+		t.Errorf("Unexpected token is sent: %s.", recorder.given)
+	}
+
+	_, err = New(NewConfig()).ContextForTeam(context.TODO(), "", "T123")
+	if err != ErrInstallationStoreNotSet {
+		t.Errorf("Expected error is not returned: %#v.", err)
+	}
+}
+
+func Test_tokenResolver_rotation(t *testing.T) {
+	store := oauth.NewMemoryInstallationStore()
+	expiresAt := time.Now().Add(12 * time.Hour)
+	installation := &oauth.Installation{
+		TeamID:            "T123",
+		BotToken:          "xoxe.xoxb-1",
+		BotRefreshToken:   "xoxe-1",
+		BotTokenExpiresAt: expiresAt,
+	}
+	err := store.Save(context.TODO(), installation)
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+
+	config := NewConfig()
+	config.ClientID = "123.456"
+	config.ClientSecret = "secret"
+	g := New(config, WithInstallationStore(store))
+
+	_, err = g.ContextForTeam(context.TODO(), "", "T123")
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+	cached, ok := g.resolver.providers[":T123"]
+	if !ok {
+		t.Fatal("Provider is not cached.")
+	}
+
+	// Refreshed token is saved back to the store
+	refreshed := &webapi.RotatingToken{
+		AccessToken:  "xoxe.xoxb-2",
+		RefreshToken: "xoxe-2",
+		ExpiresAt:    expiresAt.Add(time.Hour),
+	}
+	err = g.resolver.persister("", "T123", false, cached)(context.TODO(), refreshed)
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+	saved, err := store.Find(context.TODO(), "", "T123")
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+	if saved.BotToken != "xoxe.xoxb-2" || saved.BotRefreshToken != "xoxe-2" || !saved.BotTokenExpiresAt.Equal(refreshed.ExpiresAt) {
+		t.Errorf("Refreshed token is not saved: %#v.", saved)
+	}
+
+	// The same provider is shared
+	_, err = g.ContextForTeam(context.TODO(), "", "T123")
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+	if g.resolver.providers[":T123"] != cached {
+		t.Error("Provider is not shared.")
+	}
+
+	// Re-installation replaces the provider
+	installation.BotToken = "xoxe.xoxb-3"
+	installation.BotRefreshToken = "xoxe-3"
+	installation.BotTokenExpiresAt = expiresAt.Add(2 * time.Hour)
+	err = store.Save(context.TODO(), installation)
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+	_, err = g.ContextForTeam(context.TODO(), "", "T123")
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+	if g.resolver.providers[":T123"] == cached {
+		t.Error("Provider is not replaced.")
+	}
+}
+
+func Test_tokenResolver_refresh(t *testing.T) {
+	store := oauth.NewMemoryInstallationStore()
+	installation := &oauth.Installation{
+		TeamID:            "T123",
+		BotToken:          "xoxe.xoxb-1",
+		BotRefreshToken:   "xoxe-1",
+		BotTokenExpiresAt: time.Now().Add(time.Minute),
+	}
+	err := store.Save(context.TODO(), installation)
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+
+	// Both oauth.v2.access and auth.test are served by the transport of WebClient
+	tripper := &recordingRoundTripper{body: `{"ok": true, "access_token": "xoxe.xoxb-2", "refresh_token": "xoxe-2", "expires_in": 43200}`}
+	apiConfig := webapi.NewConfig()
+	apiConfig.Token = "xoxb-default"
+	webClient := webapi.NewClient(apiConfig, webapi.WithHTTPClient(&http.Client{Transport: tripper}))
+
+	config := NewConfig()
+	config.ClientID = "123.456"
+	config.ClientSecret = "secret"
+	g := New(config, WithWebClient(webClient), WithInstallationStore(store))
+
+	ctx, err := g.ContextForTeam(context.TODO(), "", "T123")
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- g.WebClient.Get(ctx, "auth.test", nil, &webapi.APIResponse{})
+	}()
+
+	select {
+	case err := <-errs:
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+	case <-time.NewTimer(1 * time.Second).C:
+		t.Fatal("Request is not finished.")
+
+	}
+
+	if len(tripper.requests) != 2 {
+		t.Fatalf("Unexpected number of requests are made: %d.", len(tripper.requests))
+	}
+
+	refresh := tripper.requests[0]
+	if refresh.URL.Path != "/api/oauth.v2.access" || refresh.Header.Get("Authorization") != "" {
+		t.Errorf("Unexpected refresh request is made: %s %s.", refresh.URL.Path, refresh.Header.Get("Authorization"))
+	}
+
+	request := tripper.requests[1]
+	if request.URL.Path != "/api/auth.test" || request.Header.Get("Authorization") != "Bearer xoxe.xoxb-2" {
+		t.Errorf("Unexpected request is made: %s %s.", request.URL.Path, request.Header.Get("Authorization"))
+	}
+
+	saved, err := store.Find(context.TODO(), "", "T123")
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+	if saved.BotToken != "xoxe.xoxb-2" || saved.BotRefreshToken != "xoxe-2" {
+		t.Errorf("Refreshed token is not saved: %#v.", saved)
+	}
+}
@@ -0,0 +1,60 @@
+package golack
+
+import (
+	"context"
+	"github.com/oklahomer/golack/v2/event"
+	"github.com/oklahomer/golack/v2/webapi"
+	"net/url"
+)
+
+// AddBookmark adds a bookmark to a channel.
+//
+// See https://api.slack.com/methods/bookmarks.add for official document.
+func (g *Golack) AddBookmark(ctx context.Context, bookmark *webapi.AddBookmark) (*webapi.BookmarkResponse, error) {
+	response := &webapi.BookmarkResponse{}
+	err := g.post(ctx, "bookmarks.add", bookmark, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// EditBookmark edits a bookmark in a channel.
+//
+// See https://api.slack.com/methods/bookmarks.edit for official document.
+func (g *Golack) EditBookmark(ctx context.Context, bookmark *webapi.EditBookmark) (*webapi.BookmarkResponse, error) {
+	response := &webapi.BookmarkResponse{}
+	err := g.post(ctx, "bookmarks.edit", bookmark, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// RemoveBookmark removes a bookmark from a channel.
+//
+// See https://api.slack.com/methods/bookmarks.remove for official document.
+func (g *Golack) RemoveBookmark(ctx context.Context, channelID event.ChannelID, bookmarkID string) (*webapi.APIResponse, error) {
+	req := &webapi.RemoveBookmark{
+		BookmarkID: bookmarkID,
+		ChannelID:  channelID,
+	}
+	response := &webapi.APIResponse{}
+	err := g.post(ctx, "bookmarks.remove", req, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// ListBookmarks lists bookmarks in a channel.
+//
+// See https://api.slack.com/methods/bookmarks.list for official document.
+func (g *Golack) ListBookmarks(ctx context.Context, channelID event.ChannelID) (*webapi.BookmarksResponse, error) {
+	response := &webapi.BookmarksResponse{}
+	err := g.get(ctx, "bookmarks.list", url.Values{"channel_id": []string{channelID.String()}}, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
@@ -0,0 +1,231 @@
+package golack
+
+import (
+	"context"
+	"errors"
+	"github.com/oklahomer/golack/v2/webapi"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestGolack_AddBookmark(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		bookmark := webapi.NewAddBookmark("C123", "Runbook", "https://example.com/runbook")
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.BookmarkResponse)
+				resp.OK = false
+				resp.Error = "invalid_link"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.AddBookmark(context.TODO(), bookmark)
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "bookmarks.add" || apiErr.Code != "invalid_link" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		bookmark := webapi.NewAddBookmark("C123", "Runbook", "https://example.com/runbook")
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "bookmarks.add" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if payload != bookmark {
+					t.Errorf("Given payload is not passed: %#v.", payload)
+				}
+
+				response.(*webapi.BookmarkResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.AddBookmark(context.TODO(), bookmark)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_EditBookmark(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		bookmark := webapi.NewEditBookmark("C123", "Bk123").WithTitle("On-call runbook")
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.BookmarkResponse)
+				resp.OK = false
+				resp.Error = "not_found"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.EditBookmark(context.TODO(), bookmark)
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "bookmarks.edit" || apiErr.Code != "not_found" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		bookmark := webapi.NewEditBookmark("C123", "Bk123").WithTitle("On-call runbook")
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "bookmarks.edit" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if payload != bookmark {
+					t.Errorf("Given payload is not passed: %#v.", payload)
+				}
+
+				response.(*webapi.BookmarkResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.EditBookmark(context.TODO(), bookmark)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_RemoveBookmark(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.APIResponse)
+				resp.OK = false
+				resp.Error = "not_found"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.RemoveBookmark(context.TODO(), "C123", "Bk123")
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "bookmarks.remove" || apiErr.Code != "not_found" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "bookmarks.remove" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				expected := &webapi.RemoveBookmark{BookmarkID: "Bk123", ChannelID: "C123"}
+				if !reflect.DeepEqual(payload, expected) {
+					t.Errorf("Unexpected payload is given: %#v.", payload)
+				}
+
+				response.(*webapi.APIResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.RemoveBookmark(context.TODO(), "C123", "Bk123")
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_ListBookmarks(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, _ string, _ url.Values, response interface{}) error {
+				resp := response.(*webapi.BookmarksResponse)
+				resp.OK = false
+				resp.Error = "channel_not_found"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.ListBookmarks(context.TODO(), "C123")
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "bookmarks.list" || apiErr.Code != "channel_not_found" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, slackMethod string, queryParams url.Values, response interface{}) error {
+				if slackMethod != "bookmarks.list" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if queryParams.Encode() != "channel_id=C123" {
+					t.Errorf("Unexpected query parameters are given: %s.", queryParams.Encode())
+				}
+
+				response.(*webapi.BookmarksResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.ListBookmarks(context.TODO(), "C123")
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
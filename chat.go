@@ -0,0 +1,102 @@
+package golack
+
+import (
+	"context"
+	"github.com/oklahomer/golack/v2/webapi"
+)
+
+// UpdateMessage updates a message with the given channel and timestamp.
+//
+// See https://api.slack.com/methods/chat.update for official document.
+func (g *Golack) UpdateMessage(ctx context.Context, message *webapi.UpdateMessage) (*webapi.ChatResponse, error) {
+	response := &webapi.ChatResponse{}
+	err := g.post(ctx, "chat.update", message, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// DeleteMessage deletes a message with the given channel and timestamp.
+//
+// See https://api.slack.com/methods/chat.delete for official document.
+func (g *Golack) DeleteMessage(ctx context.Context, message *webapi.DeleteMessage) (*webapi.ChatResponse, error) {
+	response := &webapi.ChatResponse{}
+	err := g.post(ctx, "chat.delete", message, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// PostEphemeral posts a message that is only visible to the given user.
+//
+// See https://api.slack.com/methods/chat.postEphemeral for official document.
+func (g *Golack) PostEphemeral(ctx context.Context, message *webapi.PostEphemeral) (*webapi.PostEphemeralResponse, error) {
+	response := &webapi.PostEphemeralResponse{}
+	err := g.post(ctx, "chat.postEphemeral", message, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// ScheduleMessage schedules a message to be posted at the given time.
+//
+// See https://api.slack.com/methods/chat.scheduleMessage for official document.
+func (g *Golack) ScheduleMessage(ctx context.Context, message *webapi.ScheduleMessage) (*webapi.ScheduleMessageResponse, error) {
+	response := &webapi.ScheduleMessageResponse{}
+	err := g.post(ctx, "chat.scheduleMessage", message, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// DeleteScheduledMessage deletes a pending scheduled message.
+//
+// See https://api.slack.com/methods/chat.deleteScheduledMessage for official document.
+func (g *Golack) DeleteScheduledMessage(ctx context.Context, message *webapi.DeleteScheduledMessage) (*webapi.APIResponse, error) {
+	response := &webapi.APIResponse{}
+	err := g.post(ctx, "chat.deleteScheduledMessage", message, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// ListScheduledMessages returns a page of scheduled messages.
+//
+// See https://api.slack.com/methods/chat.scheduledMessages.list for official document.
+func (g *Golack) ListScheduledMessages(ctx context.Context, list *webapi.ListScheduledMessages) (*webapi.ScheduledMessagesResponse, error) {
+	response := &webapi.ScheduledMessagesResponse{}
+	err := g.post(ctx, "chat.scheduledMessages.list", list, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetPermalink retrieves a permalink URL for the given message.
+//
+// See https://api.slack.com/methods/chat.getPermalink for official document.
+func (g *Golack) GetPermalink(ctx context.Context, permalink *webapi.GetPermalink) (*webapi.PermalinkResponse, error) {
+	response := &webapi.PermalinkResponse{}
+	err := g.get(ctx, "chat.getPermalink", permalink.ToURLValues(), response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// MeMessage shares a me message into a channel.
+//
+// See https://api.slack.com/methods/chat.meMessage for official document.
+func (g *Golack) MeMessage(ctx context.Context, message *webapi.MeMessage) (*webapi.ChatResponse, error) {
+	response := &webapi.ChatResponse{}
+	err := g.post(ctx, "chat.meMessage", message, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
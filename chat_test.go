@@ -0,0 +1,456 @@
+package golack
+
+import (
+	"context"
+	"errors"
+	"github.com/oklahomer/golack/v2/webapi"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestGolack_UpdateMessage(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		message := webapi.NewUpdateMessage("C123", "1.0", "updated")
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.ChatResponse)
+				resp.OK = false
+				resp.Error = "message_not_found"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.UpdateMessage(context.TODO(), message)
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "chat.update" || apiErr.Code != "message_not_found" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		message := webapi.NewUpdateMessage("C123", "1.0", "updated")
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "chat.update" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if payload != message {
+					t.Errorf("Given payload is not passed: %#v.", payload)
+				}
+
+				response.(*webapi.ChatResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.UpdateMessage(context.TODO(), message)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_DeleteMessage(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		message := webapi.NewDeleteMessage("C123", "1.0")
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.ChatResponse)
+				resp.OK = false
+				resp.Error = "message_not_found"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.DeleteMessage(context.TODO(), message)
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "chat.delete" || apiErr.Code != "message_not_found" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		message := webapi.NewDeleteMessage("C123", "1.0")
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "chat.delete" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if payload != message {
+					t.Errorf("Given payload is not passed: %#v.", payload)
+				}
+
+				response.(*webapi.ChatResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.DeleteMessage(context.TODO(), message)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_PostEphemeral(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		message := webapi.NewPostEphemeral("C123", "U123", "secret")
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.PostEphemeralResponse)
+				resp.OK = false
+				resp.Error = "user_not_in_channel"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.PostEphemeral(context.TODO(), message)
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "chat.postEphemeral" || apiErr.Code != "user_not_in_channel" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		message := webapi.NewPostEphemeral("C123", "U123", "secret")
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "chat.postEphemeral" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if payload != message {
+					t.Errorf("Given payload is not passed: %#v.", payload)
+				}
+
+				response.(*webapi.PostEphemeralResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.PostEphemeral(context.TODO(), message)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_ScheduleMessage(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		message := webapi.NewScheduleMessage("C123", time.Unix(1792193135, 0), "later")
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.ScheduleMessageResponse)
+				resp.OK = false
+				resp.Error = "time_in_past"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.ScheduleMessage(context.TODO(), message)
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "chat.scheduleMessage" || apiErr.Code != "time_in_past" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		message := webapi.NewScheduleMessage("C123", time.Unix(1792193135, 0), "later")
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "chat.scheduleMessage" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if payload != message {
+					t.Errorf("Given payload is not passed: %#v.", payload)
+				}
+
+				response.(*webapi.ScheduleMessageResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.ScheduleMessage(context.TODO(), message)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_DeleteScheduledMessage(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		message := webapi.NewDeleteScheduledMessage("C123", "Q123")
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.APIResponse)
+				resp.OK = false
+				resp.Error = "invalid_scheduled_message_id"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.DeleteScheduledMessage(context.TODO(), message)
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "chat.deleteScheduledMessage" || apiErr.Code != "invalid_scheduled_message_id" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		message := webapi.NewDeleteScheduledMessage("C123", "Q123")
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "chat.deleteScheduledMessage" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if payload != message {
+					t.Errorf("Given payload is not passed: %#v.", payload)
+				}
+
+				response.(*webapi.APIResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.DeleteScheduledMessage(context.TODO(), message)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_ListScheduledMessages(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		list := webapi.NewListScheduledMessages().WithChannelID("C123")
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.ScheduledMessagesResponse)
+				resp.OK = false
+				resp.Error = "invalid_channel"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.ListScheduledMessages(context.TODO(), list)
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "chat.scheduledMessages.list" || apiErr.Code != "invalid_channel" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		list := webapi.NewListScheduledMessages().WithChannelID("C123")
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "chat.scheduledMessages.list" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if payload != list {
+					t.Errorf("Given payload is not passed: %#v.", payload)
+				}
+
+				response.(*webapi.ScheduledMessagesResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.ListScheduledMessages(context.TODO(), list)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_GetPermalink(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, _ string, _ url.Values, response interface{}) error {
+				resp := response.(*webapi.PermalinkResponse)
+				resp.OK = false
+				resp.Error = "message_not_found"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.GetPermalink(context.TODO(), webapi.NewGetPermalink("C123", "1.0"))
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "chat.getPermalink" || apiErr.Code != "message_not_found" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, slackMethod string, queryParams url.Values, response interface{}) error {
+				if slackMethod != "chat.getPermalink" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if queryParams.Encode() != "channel=C123&message_ts=1.0" {
+					t.Errorf("Unexpected query parameters are given: %s.", queryParams.Encode())
+				}
+
+				response.(*webapi.PermalinkResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.GetPermalink(context.TODO(), webapi.NewGetPermalink("C123", "1.0"))
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_MeMessage(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		message := webapi.NewMeMessage("C123", "is happy")
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.ChatResponse)
+				resp.OK = false
+				resp.Error = "channel_not_found"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.MeMessage(context.TODO(), message)
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "chat.meMessage" || apiErr.Code != "channel_not_found" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		message := webapi.NewMeMessage("C123", "is happy")
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "chat.meMessage" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if payload != message {
+					t.Errorf("Given payload is not passed: %#v.", payload)
+				}
+
+				response.(*webapi.ChatResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.MeMessage(context.TODO(), message)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
@@ -0,0 +1,193 @@
+package golack
+
+import (
+	"context"
+	"github.com/oklahomer/golack/v2/event"
+	"github.com/oklahomer/golack/v2/webapi"
+)
+
+// GetConversationInfo retrieves information about a conversation.
+//
+// See https://api.slack.com/methods/conversations.info for official document.
+func (g *Golack) GetConversationInfo(ctx context.Context, req *webapi.ConversationsInfoRequest) (*webapi.ConversationResponse, error) {
+	response := &webapi.ConversationResponse{}
+	err := g.get(ctx, "conversations.info", req.ToURLValues(), response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetConversationHistory fetches a page of messages in a conversation.
+// To iterate over all messages, use webapi.Client.IterateHistory.
+//
+// See https://api.slack.com/methods/conversations.history for official document.
+func (g *Golack) GetConversationHistory(ctx context.Context, req *webapi.ConversationsHistoryRequest) (*webapi.ConversationsHistory, error) {
+	response := &webapi.ConversationsHistory{}
+	err := g.get(ctx, "conversations.history", req.ToURLValues(), response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetConversationReplies fetches a page of messages in a thread.
+//
+// See https://api.slack.com/methods/conversations.replies for official document.
+func (g *Golack) GetConversationReplies(ctx context.Context, req *webapi.ConversationsRepliesRequest) (*webapi.ConversationsReplies, error) {
+	response := &webapi.ConversationsReplies{}
+	err := g.get(ctx, "conversations.replies", req.ToURLValues(), response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// CreateConversation creates a public or private channel.
+//
+// See https://api.slack.com/methods/conversations.create for official document.
+func (g *Golack) CreateConversation(ctx context.Context, req *webapi.ConversationsCreateRequest) (*webapi.ConversationResponse, error) {
+	response := &webapi.ConversationResponse{}
+	err := g.post(ctx, "conversations.create", req, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// InviteToConversation invites users to a channel.
+//
+// See https://api.slack.com/methods/conversations.invite for official document.
+func (g *Golack) InviteToConversation(ctx context.Context, channelID event.ChannelID, userIDs ...event.UserID) (*webapi.ConversationResponse, error) {
+	response := &webapi.ConversationResponse{}
+	err := g.post(ctx, "conversations.invite", webapi.NewConversationsInviteRequest(channelID, userIDs...), response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// KickFromConversation removes a user from a conversation.
+//
+// See https://api.slack.com/methods/conversations.kick for official document.
+func (g *Golack) KickFromConversation(ctx context.Context, channelID event.ChannelID, userID event.UserID) (*webapi.APIResponse, error) {
+	req := &webapi.ConversationsKickRequest{
+		ChannelID: channelID,
+		UserID:    userID,
+	}
+	response := &webapi.APIResponse{}
+	err := g.post(ctx, "conversations.kick", req, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// JoinConversation joins an existing conversation.
+//
+// See https://api.slack.com/methods/conversations.join for official document.
+func (g *Golack) JoinConversation(ctx context.Context, channelID event.ChannelID) (*webapi.ConversationResponse, error) {
+	response := &webapi.ConversationResponse{}
+	err := g.post(ctx, "conversations.join", &webapi.ChannelRequest{ChannelID: channelID}, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// LeaveConversation leaves a conversation.
+//
+// See https://api.slack.com/methods/conversations.leave for official document.
+func (g *Golack) LeaveConversation(ctx context.Context, channelID event.ChannelID) (*webapi.APIResponse, error) {
+	return g.postChannel(ctx, "conversations.leave", channelID)
+}
+
+// ArchiveConversation archives a conversation.
+//
+// See https://api.slack.com/methods/conversations.archive for official document.
+func (g *Golack) ArchiveConversation(ctx context.Context, channelID event.ChannelID) (*webapi.APIResponse, error) {
+	return g.postChannel(ctx, "conversations.archive", channelID)
+}
+
+// UnarchiveConversation reverses conversation archival.
+//
+// See https://api.slack.com/methods/conversations.unarchive for official document.
+func (g *Golack) UnarchiveConversation(ctx context.Context, channelID event.ChannelID) (*webapi.APIResponse, error) {
+	return g.postChannel(ctx, "conversations.unarchive", channelID)
+}
+
+// RenameConversation renames a conversation.
+//
+// See https://api.slack.com/methods/conversations.rename for official document.
+func (g *Golack) RenameConversation(ctx context.Context, channelID event.ChannelID, name string) (*webapi.ConversationResponse, error) {
+	req := &webapi.ConversationsRenameRequest{
+		ChannelID: channelID,
+		Name:      name,
+	}
+	response := &webapi.ConversationResponse{}
+	err := g.post(ctx, "conversations.rename", req, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// SetConversationTopic sets the topic for a conversation.
+//
+// See https://api.slack.com/methods/conversations.setTopic for official document.
+func (g *Golack) SetConversationTopic(ctx context.Context, channelID event.ChannelID, topic string) (*webapi.ConversationResponse, error) {
+	req := &webapi.ConversationsSetTopicRequest{
+		ChannelID: channelID,
+		Topic:     topic,
+	}
+	response := &webapi.ConversationResponse{}
+	err := g.post(ctx, "conversations.setTopic", req, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// SetConversationPurpose sets the purpose for a conversation.
+//
+// See https://api.slack.com/methods/conversations.setPurpose for official document.
+func (g *Golack) SetConversationPurpose(ctx context.Context, channelID event.ChannelID, purpose string) (*webapi.ConversationResponse, error) {
+	req := &webapi.ConversationsSetPurposeRequest{
+		ChannelID: channelID,
+		Purpose:   purpose,
+	}
+	response := &webapi.ConversationResponse{}
+	err := g.post(ctx, "conversations.setPurpose", req, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// OpenConversation opens or resumes a direct message or a multi-person direct message.
+//
+// See https://api.slack.com/methods/conversations.open for official document.
+func (g *Golack) OpenConversation(ctx context.Context, req *webapi.ConversationsOpenRequest) (*webapi.ConversationResponse, error) {
+	response := &webapi.ConversationResponse{}
+	err := g.post(ctx, "conversations.open", req, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// CloseConversation closes a direct message or a multi-person direct message.
+//
+// See https://api.slack.com/methods/conversations.close for official document.
+func (g *Golack) CloseConversation(ctx context.Context, channelID event.ChannelID) (*webapi.APIResponse, error) {
+	return g.postChannel(ctx, "conversations.close", channelID)
+}
+
+func (g *Golack) postChannel(ctx context.Context, slackMethod string, channelID event.ChannelID) (*webapi.APIResponse, error) {
+	response := &webapi.APIResponse{}
+	err := g.post(ctx, slackMethod, &webapi.ChannelRequest{ChannelID: channelID}, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
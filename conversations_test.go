@@ -0,0 +1,834 @@
+package golack
+
+import (
+	"context"
+	"errors"
+	"github.com/oklahomer/golack/v2/webapi"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestGolack_GetConversationInfo(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, _ string, _ url.Values, response interface{}) error {
+				resp := response.(*webapi.ConversationResponse)
+				resp.OK = false
+				resp.Error = "channel_not_found"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.GetConversationInfo(context.TODO(), webapi.NewConversationsInfoRequest("C123"))
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "conversations.info" || apiErr.Code != "channel_not_found" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, slackMethod string, queryParams url.Values, response interface{}) error {
+				if slackMethod != "conversations.info" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if queryParams.Encode() != "channel=C123" {
+					t.Errorf("Unexpected query parameters are given: %s.", queryParams.Encode())
+				}
+
+				response.(*webapi.ConversationResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.GetConversationInfo(context.TODO(), webapi.NewConversationsInfoRequest("C123"))
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_GetConversationHistory(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, _ string, _ url.Values, response interface{}) error {
+				resp := response.(*webapi.ConversationsHistory)
+				resp.OK = false
+				resp.Error = "channel_not_found"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.GetConversationHistory(context.TODO(), webapi.NewConversationsHistoryRequest("C123"))
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "conversations.history" || apiErr.Code != "channel_not_found" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, slackMethod string, queryParams url.Values, response interface{}) error {
+				if slackMethod != "conversations.history" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if queryParams.Encode() != "channel=C123" {
+					t.Errorf("Unexpected query parameters are given: %s.", queryParams.Encode())
+				}
+
+				response.(*webapi.ConversationsHistory).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.GetConversationHistory(context.TODO(), webapi.NewConversationsHistoryRequest("C123"))
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_GetConversationReplies(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, _ string, _ url.Values, response interface{}) error {
+				resp := response.(*webapi.ConversationsReplies)
+				resp.OK = false
+				resp.Error = "thread_not_found"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.GetConversationReplies(context.TODO(), webapi.NewConversationsRepliesRequest("C123", "1.0"))
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "conversations.replies" || apiErr.Code != "thread_not_found" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, slackMethod string, queryParams url.Values, response interface{}) error {
+				if slackMethod != "conversations.replies" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if queryParams.Encode() != "channel=C123&ts=1.0" {
+					t.Errorf("Unexpected query parameters are given: %s.", queryParams.Encode())
+				}
+
+				response.(*webapi.ConversationsReplies).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.GetConversationReplies(context.TODO(), webapi.NewConversationsRepliesRequest("C123", "1.0"))
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_CreateConversation(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		req := &webapi.ConversationsCreateRequest{Name: "general"}
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.ConversationResponse)
+				resp.OK = false
+				resp.Error = "name_taken"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.CreateConversation(context.TODO(), req)
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "conversations.create" || apiErr.Code != "name_taken" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		req := &webapi.ConversationsCreateRequest{Name: "general"}
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "conversations.create" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if payload != req {
+					t.Errorf("Given payload is not passed: %#v.", payload)
+				}
+
+				response.(*webapi.ConversationResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.CreateConversation(context.TODO(), req)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_InviteToConversation(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.ConversationResponse)
+				resp.OK = false
+				resp.Error = "already_in_channel"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.InviteToConversation(context.TODO(), "C123", "U1", "U2")
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "conversations.invite" || apiErr.Code != "already_in_channel" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "conversations.invite" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				expected := &webapi.ConversationsInviteRequest{ChannelID: "C123", Users: "U1,U2"}
+				if !reflect.DeepEqual(payload, expected) {
+					t.Errorf("Unexpected payload is given: %#v.", payload)
+				}
+
+				response.(*webapi.ConversationResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.InviteToConversation(context.TODO(), "C123", "U1", "U2")
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_KickFromConversation(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.APIResponse)
+				resp.OK = false
+				resp.Error = "not_in_channel"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.KickFromConversation(context.TODO(), "C123", "U1")
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "conversations.kick" || apiErr.Code != "not_in_channel" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "conversations.kick" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				expected := &webapi.ConversationsKickRequest{ChannelID: "C123", UserID: "U1"}
+				if !reflect.DeepEqual(payload, expected) {
+					t.Errorf("Unexpected payload is given: %#v.", payload)
+				}
+
+				response.(*webapi.APIResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.KickFromConversation(context.TODO(), "C123", "U1")
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_JoinConversation(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.ConversationResponse)
+				resp.OK = false
+				resp.Error = "channel_not_found"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.JoinConversation(context.TODO(), "C123")
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "conversations.join" || apiErr.Code != "channel_not_found" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "conversations.join" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				expected := &webapi.ChannelRequest{ChannelID: "C123"}
+				if !reflect.DeepEqual(payload, expected) {
+					t.Errorf("Unexpected payload is given: %#v.", payload)
+				}
+
+				response.(*webapi.ConversationResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.JoinConversation(context.TODO(), "C123")
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_LeaveConversation(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.APIResponse)
+				resp.OK = false
+				resp.Error = "cant_leave_general"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.LeaveConversation(context.TODO(), "C123")
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "conversations.leave" || apiErr.Code != "cant_leave_general" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "conversations.leave" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				expected := &webapi.ChannelRequest{ChannelID: "C123"}
+				if !reflect.DeepEqual(payload, expected) {
+					t.Errorf("Unexpected payload is given: %#v.", payload)
+				}
+
+				response.(*webapi.APIResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.LeaveConversation(context.TODO(), "C123")
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_ArchiveConversation(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.APIResponse)
+				resp.OK = false
+				resp.Error = "already_archived"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.ArchiveConversation(context.TODO(), "C123")
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "conversations.archive" || apiErr.Code != "already_archived" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "conversations.archive" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				expected := &webapi.ChannelRequest{ChannelID: "C123"}
+				if !reflect.DeepEqual(payload, expected) {
+					t.Errorf("Unexpected payload is given: %#v.", payload)
+				}
+
+				response.(*webapi.APIResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.ArchiveConversation(context.TODO(), "C123")
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_UnarchiveConversation(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.APIResponse)
+				resp.OK = false
+				resp.Error = "not_archived"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.UnarchiveConversation(context.TODO(), "C123")
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "conversations.unarchive" || apiErr.Code != "not_archived" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "conversations.unarchive" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				expected := &webapi.ChannelRequest{ChannelID: "C123"}
+				if !reflect.DeepEqual(payload, expected) {
+					t.Errorf("Unexpected payload is given: %#v.", payload)
+				}
+
+				response.(*webapi.APIResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.UnarchiveConversation(context.TODO(), "C123")
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_RenameConversation(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.ConversationResponse)
+				resp.OK = false
+				resp.Error = "name_taken"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.RenameConversation(context.TODO(), "C123", "random")
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "conversations.rename" || apiErr.Code != "name_taken" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "conversations.rename" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				expected := &webapi.ConversationsRenameRequest{ChannelID: "C123", Name: "random"}
+				if !reflect.DeepEqual(payload, expected) {
+					t.Errorf("Unexpected payload is given: %#v.", payload)
+				}
+
+				response.(*webapi.ConversationResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.RenameConversation(context.TODO(), "C123", "random")
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_SetConversationTopic(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.ConversationResponse)
+				resp.OK = false
+				resp.Error = "too_long"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.SetConversationTopic(context.TODO(), "C123", "topic")
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "conversations.setTopic" || apiErr.Code != "too_long" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "conversations.setTopic" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				expected := &webapi.ConversationsSetTopicRequest{ChannelID: "C123", Topic: "topic"}
+				if !reflect.DeepEqual(payload, expected) {
+					t.Errorf("Unexpected payload is given: %#v.", payload)
+				}
+
+				response.(*webapi.ConversationResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.SetConversationTopic(context.TODO(), "C123", "topic")
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_SetConversationPurpose(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.ConversationResponse)
+				resp.OK = false
+				resp.Error = "too_long"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.SetConversationPurpose(context.TODO(), "C123", "purpose")
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "conversations.setPurpose" || apiErr.Code != "too_long" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "conversations.setPurpose" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				expected := &webapi.ConversationsSetPurposeRequest{ChannelID: "C123", Purpose: "purpose"}
+				if !reflect.DeepEqual(payload, expected) {
+					t.Errorf("Unexpected payload is given: %#v.", payload)
+				}
+
+				response.(*webapi.ConversationResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.SetConversationPurpose(context.TODO(), "C123", "purpose")
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_OpenConversation(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		req := webapi.NewConversationsOpenRequest("U1")
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.ConversationResponse)
+				resp.OK = false
+				resp.Error = "user_not_found"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.OpenConversation(context.TODO(), req)
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "conversations.open" || apiErr.Code != "user_not_found" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		req := webapi.NewConversationsOpenRequest("U1")
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "conversations.open" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if payload != req {
+					t.Errorf("Given payload is not passed: %#v.", payload)
+				}
+
+				response.(*webapi.ConversationResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.OpenConversation(context.TODO(), req)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_CloseConversation(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.APIResponse)
+				resp.OK = false
+				resp.Error = "channel_not_found"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.CloseConversation(context.TODO(), "C123")
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "conversations.close" || apiErr.Code != "channel_not_found" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "conversations.close" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				expected := &webapi.ChannelRequest{ChannelID: "C123"}
+				if !reflect.DeepEqual(payload, expected) {
+					t.Errorf("Unexpected payload is given: %#v.", payload)
+				}
+
+				response.(*webapi.APIResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.CloseConversation(context.TODO(), "C123")
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
@@ -1431,6 +1431,7 @@ var expectedPayloads = map[string]interface{}{
 		TypedEvent: TypedEvent{
 			Type: "reconnect_url",
 		},
+		URL: "wss://cerberus-xxxx.lb.slack-msgs.com/websocket/xxxxxxxx",
 	},
 	"resources_added": &ResourcesAdded{
 		TypedEvent: TypedEvent{
@@ -847,9 +847,11 @@ type ReactionRemoved struct {
 }
 
 // ReconnectURL is currently unsupported and experimental
+// The given URL can be used to reconnect within a short period of time.
 // https://api.slack.com/events/reconnect_url
 type ReconnectURL struct {
 	TypedEvent
+	URL string `json:"url"`
 }
 
 // ResourcesAdded is delivered as users install your Slack app, add your app to channels and conversations,
@@ -1246,21 +1248,26 @@ type User struct {
 }
 
 type View struct {
-	ID                 ViewID     `json:"id"`
-	TeamID             TeamID     `json:"team_id"`
-	Type               string     `json:"type"`
-	Blocks             []Block    `json:"blocks"`
-	PrivateMetadata    string     `json:"private_metadata"`
-	CallbackID         string     `json:"callback_id"`
-	State              *ViewState `json:"state"`
-	Hash               string     `json:"hash"`
-	ClearOnClose       bool       `json:"clear_on_close"`
-	NotifyOnClose      bool       `json:"notify_on_close"`
-	RootViewID         string     `json:"root_view_id"`
-	AppID              AppID      `json:"app_id"`
-	ExternalID         string     `json:"external_id"`
-	AppInstalledTeamID TeamID     `json:"app_installed_team_id"`
-	BotID              BotID      `json:"bot_id"`
+	ID                 ViewID                 `json:"id"`
+	TeamID             TeamID                 `json:"team_id"`
+	Type               string                 `json:"type"`
+	Title              *TextCompositionObject `json:"title"`
+	Submit             *TextCompositionObject `json:"submit"`
+	Close              *TextCompositionObject `json:"close"`
+	SubmitDisabled     bool                   `json:"submit_disabled"`
+	Blocks             []Block                `json:"blocks"`
+	PrivateMetadata    string                 `json:"private_metadata"`
+	CallbackID         string                 `json:"callback_id"`
+	State              *ViewState             `json:"state"`
+	Hash               string                 `json:"hash"`
+	ClearOnClose       bool                   `json:"clear_on_close"`
+	NotifyOnClose      bool                   `json:"notify_on_close"`
+	PreviousViewID     ViewID                 `json:"previous_view_id"`
+	RootViewID         string                 `json:"root_view_id"`
+	AppID              AppID                  `json:"app_id"`
+	ExternalID         string                 `json:"external_id"`
+	AppInstalledTeamID TeamID                 `json:"app_installed_team_id"`
+	BotID              BotID                  `json:"bot_id"`
 }
 
 func (v *View) UnmarshalJSON(b []byte) error {
@@ -9,13 +9,26 @@ import (
 
 // https://api.slack.com/events-api#callback_field_overview
 type outer struct {
-	Token       string           `json:"token"`
-	TeamID      string           `json:"team_id"`
-	APIAppID    string           `json:"api_app_id"`
-	Type        string           `json:"type"`
-	AuthedUsers []string         `json:"authed_users"`
-	EventID     event.EventID    `json:"event_id"`
-	EventTime   *event.TimeStamp `json:"event_time"`
+	Token          string           `json:"token"`
+	TeamID         string           `json:"team_id"`
+	EnterpriseID   string           `json:"enterprise_id"`
+	APIAppID       string           `json:"api_app_id"`
+	Type           string           `json:"type"`
+	AuthedUsers    []string         `json:"authed_users"`
+	Authorizations []*Authorization `json:"authorizations"`
+	EventID        event.EventID    `json:"event_id"`
+	EventTime      *event.TimeStamp `json:"event_time"`
+}
+
+// Authorization represents an installation of the app that the event is visible to.
+// Slack gives one of the installations, so call apps.event.authorizations.list to retrieve all of them.
+// https://api.slack.com/changelog/2020-09-15-events-api-truncate-authed-users
+type Authorization struct {
+	EnterpriseID        string       `json:"enterprise_id"`
+	TeamID              event.TeamID `json:"team_id"`
+	UserID              event.UserID `json:"user_id"`
+	IsBot               bool         `json:"is_bot"`
+	IsEnterpriseInstall bool         `json:"is_enterprise_install"`
 }
 
 // EventWrapper contains given event, metadata and the request.
@@ -25,7 +25,14 @@ var expectedPayloads = map[string]interface{}{
 			APIAppID:    "A0FFV41KK",
 			Type:        "event_callback",
 			AuthedUsers: []string{"U061F7AUR"},
-			EventID:     "Ev9UQ52YNA",
+			Authorizations: []*Authorization{
+				{
+					TeamID: "T061EG9RZ",
+					UserID: "U0M4RL1NY",
+					IsBot:  true,
+				},
+			},
+			EventID: "Ev9UQ52YNA",
 			EventTime: &event.TimeStamp{
 				Time:          time.Unix(1234567890, 0),
 				OriginalValue: "1234567890",
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/oklahomer/golack/v2"
+	"github.com/oklahomer/golack/v2/eventsapi"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func main() {
+	config := golack.NewConfig()
+	config.Token = os.Getenv("APP_TOKEN")
+	config.AppToken = os.Getenv("APP_LEVEL_TOKEN")
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	g := golack.New(config)
+	receiver := eventsapi.NewDefaultEventReceiver(func(wrapper *eventsapi.EventWrapper) {
+		log.Printf("Event: %T, %+v", wrapper.Event, wrapper.Event)
+	})
+	errChan := g.RunSocketMode(ctx, receiver)
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+
+	select {
+	case <-c:
+		fmt.Println("FINISH")
+		cancel()
+
+	case err := <-errChan:
+		fmt.Printf("ERROR: %s\n", err.Error())
+		cancel()
+	}
+}
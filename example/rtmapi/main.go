@@ -3,7 +3,6 @@ package main
 import (
 	"context"
 	"fmt"
-	"github.com/oklahomer/golack/v2/event"
 	"github.com/oklahomer/golack/v2/rtmapi"
 	"github.com/oklahomer/golack/v2/webapi"
 	"os"
@@ -36,29 +35,31 @@ func main() {
 	}
 
 	// Receive events over WebSocket connection
+	stream := rtmapi.Stream(ctx, conn)
 	go func() {
 		for {
 			select {
-			case <-ctx.Done():
-				return
-
-			default:
-				payload, err := conn.Receive()
-
-				if err == event.ErrEmptyPayload {
-					continue
+			case payload, ok := <-stream.Events():
+				if !ok {
+					return
 				}
+				fmt.Printf("PAYLOAD: %+v", payload)
 
-				if err != nil {
-					fmt.Printf("ERROR: %+v", err)
-					continue
+			case err, ok := <-stream.PayloadErrors():
+				if !ok {
+					return
 				}
+				fmt.Printf("ERROR: %+v", err)
 
-				fmt.Printf("PAYLOAD: %+v", payload)
 			}
 		}
 	}()
 
+	go func() {
+		<-stream.Done()
+		fmt.Printf("STREAM STOPPED: %+v", stream.Err())
+	}()
+
 	// Stop interaction
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
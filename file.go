@@ -0,0 +1,19 @@
+package golack
+
+import (
+	"context"
+	"github.com/oklahomer/golack/v2/webapi"
+)
+
+// UploadFile uploads a file with multipart/form-data and shares it with the channels given by webapi.FileUpload.
+// To upload with the newer flow that replaces files.upload, use webapi.Client.UploadFileExternal.
+//
+// See https://api.slack.com/methods/files.upload for official document.
+func (g *Golack) UploadFile(ctx context.Context, upload *webapi.FileUpload) (*webapi.FileResponse, error) {
+	response := &webapi.FileResponse{}
+	err := g.post(ctx, "files.upload", upload, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
@@ -0,0 +1,65 @@
+package golack
+
+import (
+	"context"
+	"errors"
+	"github.com/oklahomer/golack/v2/webapi"
+	"strings"
+	"testing"
+)
+
+func TestGolack_UploadFile(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		upload := webapi.NewFileUpload("hello.txt", strings.NewReader("hello"))
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.FileResponse)
+				resp.OK = false
+				resp.Error = "invalid_channel"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.UploadFile(context.TODO(), upload)
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "files.upload" || apiErr.Code != "invalid_channel" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		upload := webapi.NewFileUpload("hello.txt", strings.NewReader("hello"))
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "files.upload" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if payload != upload {
+					t.Errorf("Given payload is not passed: %#v.", payload)
+				}
+
+				response.(*webapi.FileResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.UploadFile(context.TODO(), upload)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
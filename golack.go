@@ -4,6 +4,9 @@
 //   - eventsapi ... Events API ( https://api.slack.com/events-api )
 //   - webapi ... Web API ( https://api.slack.com/web )
 //   - rtmapi ... RTM API ( https://api.slack.com/rtm )
+//   - socketmode ... Socket Mode ( https://api.slack.com/apis/connections/socket )
+//   - state ... Workspace state tracked with RTM API events
+//   - oauth ... OAuth v2 installation flow ( https://api.slack.com/authentication/oauth-v2 )
 package golack
 
 import (
@@ -12,6 +15,7 @@ import (
 	"fmt"
 	"github.com/oklahomer/golack/v2/eventsapi"
 	"github.com/oklahomer/golack/v2/rtmapi"
+	"github.com/oklahomer/golack/v2/socketmode"
 	"github.com/oklahomer/golack/v2/webapi"
 	"net/http"
 	"net/url"
@@ -23,19 +27,30 @@ import (
 type Config struct {
 	AppSecret      string        `json:"app_secret" yaml:"app_secret"`
 	Token          string        `json:"token" yaml:"token"`
+	AppToken       string        `json:"app_token" yaml:"app_token"`
 	ListenPort     int           `json:"listen_port" yaml:"listen_port"`
 	RequestTimeout time.Duration `json:"request_timeout" yaml:"request_timeout"`
+
+	// MethodTimeouts overrides RequestTimeout for specific Web API methods such as files.upload.
+	MethodTimeouts map[string]time.Duration `json:"method_timeouts" yaml:"method_timeouts"`
+
+	// ClientID and ClientSecret are used to refresh the tokens saved in oauth.InstallationStore when token rotation is enabled.
+	ClientID     string `json:"client_id" yaml:"client_id"`
+	ClientSecret string `json:"client_secret" yaml:"client_secret"`
 }
 
 // NewConfig returns initialized Config struct with default settings.
-// AppSecret and Token are empty at this point. They can be set/updated by feeding this instance to json.Unmarshal/yaml.Unmarshal
+// AppSecret, Token and AppToken are empty at this point. They can be set/updated by feeding this instance to json.Unmarshal/yaml.Unmarshal
 // or by direct assignment.
 func NewConfig() *Config {
 	return &Config{
 		AppSecret:      "",
 		Token:          "",
+		AppToken:       "",
 		ListenPort:     8080,
 		RequestTimeout: 3 * time.Second,
+		ClientID:       "",
+		ClientSecret:   "",
 	}
 }
 
@@ -55,11 +70,22 @@ func WithWebClient(wc WebClient) Option {
 	}
 }
 
+// WithAppWebClient provides a way to use pre-configured WebClient implementation for app-level token.
+// This client is used to call apps.connections.open method to establish Socket Mode connection.
+// Pass the returned Option to New().
+func WithAppWebClient(wc WebClient) Option {
+	return func(g *Golack) {
+		g.AppWebClient = wc
+	}
+}
+
 // Golack works as a kind of facade to provide higher level interface to work with Events API, Web API and RTM API.
 // For more customizability, use each sub-package that corresponds to each API.
 type Golack struct {
-	WebClient WebClient
-	config    *Config
+	WebClient    WebClient
+	AppWebClient WebClient
+	config       *Config
+	resolver     *tokenResolver
 }
 
 // New builds a new Golack instance with given config and options.
@@ -74,15 +100,37 @@ func New(config *Config, options ...Option) *Golack {
 
 	// If WebClient is not set with Option, then built one with default settings
 	if g.WebClient == nil {
-		apiConfig := webapi.NewConfig()
-		apiConfig.Token = g.config.Token
-		apiConfig.RequestTimeout = g.config.RequestTimeout
-		g.WebClient = webapi.NewClient(apiConfig)
+		g.WebClient = webapi.NewClient(g.newAPIConfig(g.config.Token))
+	}
+
+	// Likewise, build one with app-level token to work with Socket Mode
+	if g.AppWebClient == nil {
+		g.AppWebClient = webapi.NewClient(g.newAPIConfig(g.config.AppToken))
+	}
+
+	// Refresh rotating tokens with the same HTTP client, retry policy and timeouts as other Web API calls
+	if g.resolver != nil {
+		if client, ok := g.WebClient.(*webapi.Client); ok {
+			g.resolver.refreshClient = client
+		} else {
+			g.resolver.refreshClient = webapi.NewClient(g.newAPIConfig(""))
+		}
 	}
 
 	return g
 }
 
+// newAPIConfig builds webapi.Config with the given token and the timeout settings of Config.
+func (g *Golack) newAPIConfig(token string) *webapi.Config {
+	apiConfig := webapi.NewConfig()
+	apiConfig.Token = token
+	apiConfig.RequestTimeout = g.config.RequestTimeout
+	for method, timeout := range g.config.MethodTimeouts {
+		apiConfig.MethodTimeouts[method] = timeout
+	}
+	return apiConfig
+}
+
 // PostMessage posts a postMessage to Slack.
 //
 // See https://api.slack.com/methods/chat.postMessage for official document.
@@ -93,28 +141,49 @@ func (g *Golack) PostMessage(ctx context.Context, postMessage *webapi.PostMessag
 		return nil, err
 	}
 
-	if response.OK != true {
-		return nil, fmt.Errorf("failed chat.postMessage request: %s", response.Error)
+	err = response.Err("chat.postMessage")
+	if err != nil {
+		return nil, err
 	}
 
 	return response, nil
 }
 
 // ConnectRTM connects to Slack WebSocket server.
+// Given options are passed to rtmapi.Connect to customize the connection such as proxy, TLS configuration and compression.
 //
 // See https://api.slack.com/rtm for official document.
-func (g *Golack) ConnectRTM(ctx context.Context) (rtmapi.Connection, error) {
+func (g *Golack) ConnectRTM(ctx context.Context, options ...rtmapi.ConnectOption) (rtmapi.Connection, error) {
+	url, err := g.rtmURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return rtmapi.Connect(ctx, url, options...)
+}
+
+// SuperviseRTM builds rtmapi.Supervisor that owns the lifecycle of RTM connection.
+// Each time a new connection is required, the Supervisor calls rtm.connect method to retrieve the WebSocket URL.
+// Call Run of the returned Supervisor to establish the connection, and receive events from its Events channel.
+//
+// See https://api.slack.com/rtm for official document.
+func (g *Golack) SuperviseRTM(options ...rtmapi.SupervisorOption) *rtmapi.Supervisor {
+	return rtmapi.NewSupervisor(g.rtmURL, options...)
+}
+
+func (g *Golack) rtmURL(ctx context.Context) (string, error) {
 	rtmStart := &webapi.RTMStart{}
 	err := g.WebClient.Get(ctx, "rtm.connect", nil, rtmStart)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	if rtmStart.OK != true {
-		return nil, fmt.Errorf("failed rtm.start request: %s, %v", rtmStart.Error, err)
+	err = rtmStart.Err("rtm.connect")
+	if err != nil {
+		return "", err
 	}
 
-	return rtmapi.Connect(ctx, rtmStart.URL)
+	return rtmStart.URL, nil
 }
 
 // RunServer starts a server to interact with Events API.
@@ -153,3 +222,85 @@ func (g *Golack) RunServer(ctx context.Context, receiver eventsapi.EventReceiver
 
 	return errChan
 }
+
+// ConnectSocketMode connects to Slack WebSocket server with Socket Mode.
+// An app-level token must be set to Config.AppToken or the WebClient given by WithAppWebClient must be configured with one.
+// Given options are passed to socketmode.Connect to customize the connection such as proxy and TLS configuration.
+//
+// See https://api.slack.com/apis/connections/socket for official document.
+func (g *Golack) ConnectSocketMode(ctx context.Context, options ...socketmode.ConnectOption) (socketmode.Connection, error) {
+	connectionsOpen := &webapi.AppsConnectionsOpen{}
+	err := g.AppWebClient.Post(ctx, "apps.connections.open", url.Values{}, connectionsOpen)
+	if err != nil {
+		return nil, err
+	}
+
+	err = connectionsOpen.Err("apps.connections.open")
+	if err != nil {
+		return nil, err
+	}
+
+	return socketmode.Connect(ctx, connectionsOpen.URL, options...)
+}
+
+// RunSocketMode connects to Slack with Socket Mode and passes incoming events to the receiver.
+// Just like RunServer, the given receiver works as-is since events are decoded in the same manner as Events API.
+// The connection runs in another goroutine so this method is not blocking.
+// When Slack requests disconnection, this establishes a new connection.
+// To pass and notify the error state, this returns a channel that passes the error.
+// When the error is returned from the channel, the connection is already closed.
+// Given options are passed to socketmode.Serve to handle interactive and slash command payloads
+// with socketmode.WithInteractiveHandler and socketmode.WithSlashCommandHandler.
+//
+// See https://api.slack.com/apis/connections/socket for official document.
+func (g *Golack) RunSocketMode(ctx context.Context, receiver eventsapi.EventReceiver, options ...socketmode.ServeOption) <-chan error {
+	errChan := make(chan error, 1)
+
+	go func() {
+		for {
+			conn, err := g.ConnectSocketMode(ctx)
+			if err != nil {
+				errChan <- err
+				return
+			}
+
+			err = socketmode.Serve(ctx, conn, receiver, options...)
+			//noinspection ALL
+			conn.Close()
+
+			var disconnected *socketmode.DisconnectedError
+			if errors.As(err, &disconnected) {
+				// Slack requested a reconnection
+				continue
+			}
+
+			errChan <- err
+			return
+		}
+	}()
+
+	return errChan
+}
+
+// result is implemented by any response struct that embeds webapi.APIResponse.
+type result interface {
+	Err(slackMethod string) error
+}
+
+// get calls the given method with GET request and returns *webapi.APIError when the response represents a failure.
+func (g *Golack) get(ctx context.Context, slackMethod string, queryParams url.Values, response result) error {
+	err := g.WebClient.Get(ctx, slackMethod, queryParams, response)
+	if err != nil {
+		return err
+	}
+	return response.Err(slackMethod)
+}
+
+// post calls the given method with POST request and returns *webapi.APIError when the response represents a failure.
+func (g *Golack) post(ctx context.Context, slackMethod string, payload interface{}, response result) error {
+	err := g.WebClient.Post(ctx, slackMethod, payload, response)
+	if err != nil {
+		return err
+	}
+	return response.Err(slackMethod)
+}
@@ -5,8 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"github.com/oklahomer/golack/v2/eventsapi"
+	"github.com/oklahomer/golack/v2/rtmapi"
+	"github.com/oklahomer/golack/v2/socketmode"
 	"github.com/oklahomer/golack/v2/testutil"
 	"github.com/oklahomer/golack/v2/webapi"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/url"
@@ -28,6 +31,23 @@ func (wc *DummyWebClient) Post(ctx context.Context, slackMethod string, payload
 	return wc.PostFunc(ctx, slackMethod, payload, response)
 }
 
+// recordingRoundTripper records the given request and returns the response with the given body.
+type recordingRoundTripper struct {
+	body     string
+	requests []*http.Request
+}
+
+func (r *recordingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.requests = append(r.requests, req)
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(strings.NewReader(r.body)),
+		Request:    req,
+	}, nil
+}
+
 type DummyReceiver struct {
 	ReceiveFunc func(wrapper *eventsapi.EventWrapper)
 }
@@ -63,6 +83,18 @@ func TestWithWebClient(t *testing.T) {
 	}
 }
 
+func TestWithAppWebClient(t *testing.T) {
+	webClient := &DummyWebClient{}
+	option := WithAppWebClient(webClient)
+	g := &Golack{}
+
+	option(g)
+
+	if g.AppWebClient != webClient {
+		t.Errorf("Specified WebClient is not set.")
+	}
+}
+
 func TestNew(t *testing.T) {
 	config := &Config{}
 	optionCalled := false
@@ -78,6 +110,28 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestGolack_newAPIConfig(t *testing.T) {
+	config := &Config{
+		RequestTimeout: 3 * time.Second,
+		MethodTimeouts: map[string]time.Duration{"files.upload": 1 * time.Minute},
+	}
+	g := &Golack{config: config}
+
+	apiConfig := g.newAPIConfig("xapp-dummy")
+
+	if apiConfig.Token != "xapp-dummy" {
+		t.Errorf("Expected token is not set: %s.", apiConfig.Token)
+	}
+
+	if apiConfig.RequestTimeout != config.RequestTimeout {
+		t.Errorf("Expected timeout is not set: %s.", apiConfig.RequestTimeout)
+	}
+
+	if apiConfig.MethodTimeouts["files.upload"] != 1*time.Minute {
+		t.Errorf("Expected method timeout is not set: %#v.", apiConfig.MethodTimeouts)
+	}
+}
+
 func TestGolack_PostMessage(t *testing.T) {
 	t.Run("Web API returns error status", func(t *testing.T) {
 		expectedErr := errors.New("DUMMY")
@@ -116,6 +170,14 @@ func TestGolack_PostMessage(t *testing.T) {
 		if err == nil {
 			t.Fatal("Expected error is not returned.")
 		}
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected type of error is not returned: %#v", err)
+		}
+		if apiErr.Method != "chat.postMessage" || apiErr.Code != "some error" {
+			t.Errorf("Unexpected error is returned: %#v", apiErr)
+		}
 	})
 
 	t.Run("success", func(t *testing.T) {
@@ -210,6 +272,205 @@ func TestGolack_ConnectRTM(t *testing.T) {
 			}
 		})
 	})
+
+	t.Run("connect with options", func(t *testing.T) {
+		testutil.RunWithWebSocket(func(addr net.Addr) {
+			webClient := &DummyWebClient{
+				GetFunc: func(_ context.Context, _ string, _ url.Values, response interface{}) error {
+					resp := response.(*webapi.RTMStart)
+					resp.OK = true
+					resp.URL = fmt.Sprintf("ws://%s%s", addr, "/ping")
+					return nil
+				},
+			}
+			g := &Golack{
+				WebClient: webClient,
+			}
+
+			proxyCalled := false
+			rtm, err := g.ConnectRTM(context.Background(), rtmapi.WithProxy(func(_ *http.Request) (*url.URL, error) {
+				proxyCalled = true
+				return nil, nil
+			}))
+			if err != nil {
+				t.Fatalf("Unexpected error is returned: %s", err.Error())
+			}
+			//noinspection ALL
+			defer rtm.Close()
+
+			if !proxyCalled {
+				t.Error("Given option is not applied.")
+			}
+		})
+	})
+}
+
+func TestGolack_SuperviseRTM(t *testing.T) {
+	testutil.RunWithWebSocket(func(addr net.Addr) {
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, slackMethod string, _ url.Values, response interface{}) error {
+				if slackMethod != "rtm.connect" {
+					t.Errorf("Unexpected method is called: %s", slackMethod)
+				}
+				resp := response.(*webapi.RTMStart)
+				resp.OK = true
+				resp.URL = fmt.Sprintf("ws://%s%s", addr, "/goodbye")
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		supervisor := g.SuperviseRTM()
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- supervisor.Run(ctx)
+		}()
+
+		select {
+		case <-supervisor.Events():
+			// O.K.
+
+		case <-time.NewTimer(1 * time.Second).C:
+			t.Error("Event is not given.")
+
+		}
+
+		cancel()
+		<-errCh
+	})
+}
+
+func TestGolack_ConnectSocketMode(t *testing.T) {
+	t.Run("Web API returns error status", func(t *testing.T) {
+		expectedErr := errors.New("DUMMY")
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, _ interface{}) error {
+				return expectedErr
+			},
+		}
+		g := &Golack{
+			AppWebClient: webClient,
+		}
+
+		_, err := g.ConnectSocketMode(context.Background())
+		if err == nil {
+			t.Fatal("Error is not returned.")
+		}
+		if err != expectedErr {
+			t.Fatalf("Expected error is not returned: %+v", err)
+		}
+	})
+
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.AppsConnectionsOpen)
+				resp.OK = false
+				resp.Error = "invalid_auth"
+				return nil
+			},
+		}
+		g := &Golack{
+			AppWebClient: webClient,
+		}
+
+		_, err := g.ConnectSocketMode(context.Background())
+		if err == nil {
+			t.Fatal("Expected error is not returned.")
+		}
+	})
+
+	t.Run("connect WebSocket server", func(t *testing.T) {
+		testutil.RunWithWebSocket(func(addr net.Addr) {
+			webClient := &DummyWebClient{
+				PostFunc: func(_ context.Context, slackMethod string, _ interface{}, response interface{}) error {
+					if slackMethod != "apps.connections.open" {
+						t.Errorf("Unexpected method is called: %s", slackMethod)
+					}
+					resp := response.(*webapi.AppsConnectionsOpen)
+					resp.OK = true
+					resp.URL = fmt.Sprintf("ws://%s%s", addr, "/socket_mode")
+					return nil
+				},
+			}
+			g := &Golack{
+				AppWebClient: webClient,
+			}
+
+			conn, err := g.ConnectSocketMode(context.Background())
+			if err != nil {
+				t.Fatalf("Unexpected error is returned: %s", err.Error())
+			}
+			defer conn.Close()
+
+			_, err = conn.Receive()
+			if err != nil {
+				t.Fatalf("Unexpected error is returned on Receive: %s", err.Error())
+			}
+		})
+	})
+}
+
+func TestGolack_RunSocketMode(t *testing.T) {
+	expectedErr := errors.New("DUMMY")
+	webClient := &DummyWebClient{
+		PostFunc: func(_ context.Context, _ string, _ interface{}, _ interface{}) error {
+			return expectedErr
+		},
+	}
+	g := &Golack{
+		AppWebClient: webClient,
+	}
+
+	errCh := g.RunSocketMode(context.Background(), &DummyReceiver{})
+
+	select {
+	case err := <-errCh:
+		if err != expectedErr {
+			t.Errorf("Unexpected error is returned: %+v", err)
+		}
+
+	case <-time.NewTimer(1 * time.Second).C:
+		t.Fatal("Expected error is not returned.")
+	}
+}
+
+func TestGolack_RunSocketMode_Options(t *testing.T) {
+	testutil.RunWithWebSocket(func(addr net.Addr) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.AppsConnectionsOpen)
+				resp.OK = true
+				resp.URL = fmt.Sprintf("ws://%s%s", addr, "/socket_mode_interactive")
+				return nil
+			},
+		}
+		g := &Golack{
+			AppWebClient: webClient,
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		handled := make(chan *socketmode.Interactive, 1)
+		g.RunSocketMode(ctx, &DummyReceiver{}, socketmode.WithInteractiveHandler(func(interactive *socketmode.Interactive) interface{} {
+			handled <- interactive
+			return nil
+		}))
+
+		select {
+		case interactive := <-handled:
+			if interactive.InteractionType != "block_actions" {
+				t.Errorf("Unexpected payload is given: %#v.", interactive)
+			}
+
+		case <-time.NewTimer(1 * time.Second).C:
+			t.Fatal("Interactive handler is not called.")
+		}
+	})
 }
 
 func TestGolack_RunServer(t *testing.T) {
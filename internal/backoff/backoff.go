@@ -0,0 +1,26 @@
+// Package backoff provides the calculation of exponential backoff intervals shared by RTM reconnection and Web API retries.
+package backoff
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Duration returns the interval to wait before the given attempt.
+// The attempt starts from 0.
+// The interval grows exponentially by multiplier from initial until it reaches max, and is randomized by jitter.
+// The jitter is the ratio, between 0 and 1, of the interval that is randomized.
+func Duration(initial time.Duration, max time.Duration, multiplier float64, jitter float64, attempt int) time.Duration {
+	interval := float64(initial) * math.Pow(multiplier, float64(attempt))
+	if interval > float64(max) || math.IsInf(interval, 0) {
+		interval = float64(max)
+	}
+
+	if jitter > 0 {
+		delta := interval * jitter
+		interval = interval - delta + rand.Float64()*(2*delta)
+	}
+
+	return time.Duration(interval)
+}
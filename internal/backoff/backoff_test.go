@@ -0,0 +1,35 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDuration(t *testing.T) {
+	tests := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{attempt: 0, expected: 1 * time.Second},
+		{attempt: 1, expected: 2 * time.Second},
+		{attempt: 3, expected: 8 * time.Second},
+		{attempt: 4, expected: 10 * time.Second},
+		{attempt: 1000, expected: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		d := Duration(1*time.Second, 10*time.Second, 2, 0, tt.attempt)
+		if d != tt.expected {
+			t.Errorf("Expected %s on attempt %d, but was %s.", tt.expected, tt.attempt, d)
+		}
+	}
+}
+
+func TestDuration_Jitter(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		d := Duration(10*time.Second, 10*time.Second, 2, 0.2, 0)
+		if d < 8*time.Second || d > 12*time.Second {
+			t.Fatalf("Duration is out of expected range: %s.", d)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/oklahomer/golack/v2/event"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// FileInstallationStore is an InstallationStore implementation that saves each installation as a JSON file in a directory.
+// The files contain tokens, so they are created with 0600 permission.
+// This is suitable for a single process. Use a database-backed implementation to share installations among multiple processes.
+type FileInstallationStore struct {
+	dir   string
+	mutex *sync.RWMutex
+}
+
+var _ InstallationStore = (*FileInstallationStore)(nil)
+
+// NewFileInstallationStore creates FileInstallationStore that saves installations in the given directory.
+// The directory is created when it does not exist.
+func NewFileInstallationStore(dir string) (*FileInstallationStore, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileInstallationStore{
+		dir:   dir,
+		mutex: &sync.RWMutex{},
+	}, nil
+}
+
+// Save writes the given installation to a file.
+// The file is replaced atomically so a concurrent reader never sees a partially written file.
+func (s *FileInstallationStore) Save(_ context.Context, installation *Installation) error {
+	path, err := s.path(keyOf(installation))
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(installation)
+	if err != nil {
+		return fmt.Errorf("failed to serialize installation: %w", err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// ioutil.TempFile creates the file with 0600 permission
+	file, err := ioutil.TempFile(s.dir, ".installation-*")
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(b)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		//noinspection ALL
+		os.Remove(file.Name())
+		return err
+	}
+
+	err = os.Rename(file.Name(), path)
+	if err != nil {
+		//noinspection ALL
+		os.Remove(file.Name())
+		return err
+	}
+	return nil
+}
+
+// Find reads the installation from the file.
+func (s *FileInstallationStore) Find(_ context.Context, enterpriseID string, teamID event.TeamID) (*Installation, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return find(enterpriseID, teamID, func(key installationKey) (*Installation, error) {
+		path, err := s.path(key)
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			return nil, ErrInstallationNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		installation := &Installation{}
+		err = json.Unmarshal(b, installation)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize installation in %s: %w", path, err)
+		}
+		return installation, nil
+	})
+}
+
+// Delete removes the file of the installation.
+func (s *FileInstallationStore) Delete(_ context.Context, enterpriseID string, teamID event.TeamID) error {
+	path, err := s.path(installationKey{enterpriseID: enterpriseID, teamID: teamID})
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err = os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// path returns the file path for the given key.
+// IDs are validated since they may come from an incoming request and must not escape the directory.
+func (s *FileInstallationStore) path(key installationKey) (string, error) {
+	for _, id := range []string{key.enterpriseID, key.teamID.String()} {
+		if strings.ContainsAny(id, `/\.`) {
+			return "", fmt.Errorf("invalid ID is given: %s", id)
+		}
+	}
+
+	enterpriseID := key.enterpriseID
+	if enterpriseID == "" {
+		enterpriseID = "none"
+	}
+	teamID := key.teamID.String()
+	if teamID == "" {
+		teamID = "none"
+	}
+	return filepath.Join(s.dir, fmt.Sprintf("%s-%s.json", enterpriseID, teamID)), nil
+}
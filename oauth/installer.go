@@ -0,0 +1,313 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/oklahomer/golack/v2/webapi"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidState is returned when the state parameter of the callback does not match the one issued to the user's browser or is expired.
+	// This usually means the callback is forged or the user took too long to complete the installation.
+	ErrInvalidState = errors.New("invalid OAuth state parameter")
+
+	// ErrMissingCode is returned when the callback does not have the code parameter.
+	ErrMissingCode = errors.New("missing OAuth code parameter")
+
+	// ErrAuthorizationDenied is returned when the user cancels the installation or Slack rejects the authorization request.
+	ErrAuthorizationDenied = errors.New("authorization is denied")
+)
+
+// WebClient defines an interface that abstracts the webapi.Client to call oauth.v2.access method.
+type WebClient interface {
+	Post(ctx context.Context, slackMethod string, payload interface{}, response interface{}) error
+}
+
+// SuccessHandler is called when the installation is saved.
+type SuccessHandler func(w http.ResponseWriter, r *http.Request, installation *Installation)
+
+// FailureHandler is called when the installation fails.
+// The given error wraps ErrInvalidState, ErrMissingCode or ErrAuthorizationDenied on invalid callbacks,
+// or is *webapi.APIError when oauth.v2.access fails.
+type FailureHandler func(w http.ResponseWriter, r *http.Request, err error)
+
+// Option defines a function that customizes the Installer.
+type Option func(*Installer)
+
+// WithWebClient provides a way to use pre-configured WebClient implementation to call oauth.v2.access method.
+// Pass the returned Option to NewInstaller().
+func WithWebClient(wc WebClient) Option {
+	return func(i *Installer) {
+		i.webClient = wc
+	}
+}
+
+// WithSuccessHandler sets a handler that renders the response on successful installation.
+// By default, a plain text message is rendered.
+func WithSuccessHandler(handler SuccessHandler) Option {
+	return func(i *Installer) {
+		i.onSuccess = handler
+	}
+}
+
+// WithFailureHandler sets a handler that renders the response on failed installation.
+// By default, a plain text message is rendered with an appropriate status code.
+func WithFailureHandler(handler FailureHandler) Option {
+	return func(i *Installer) {
+		i.onFailure = handler
+	}
+}
+
+// Installer provides the http.Handler implementations to complete the OAuth v2 installation flow.
+type Installer struct {
+	config    *Config
+	store     InstallationStore
+	webClient WebClient
+	onSuccess SuccessHandler
+	onFailure FailureHandler
+	now       func() time.Time
+}
+
+// NewInstaller builds a new Installer instance with given config, store and options.
+func NewInstaller(config *Config, store InstallationStore, options ...Option) *Installer {
+	i := &Installer{
+		config:    config,
+		store:     store,
+		onSuccess: defaultSuccessHandler,
+		onFailure: defaultFailureHandler,
+		now:       time.Now,
+	}
+
+	// Apply options to change specific behaviors
+	for _, opt := range options {
+		opt(i)
+	}
+
+	// If WebClient is not set with Option, then build one without a token since client credentials are sent instead
+	if i.webClient == nil {
+		i.webClient = webapi.NewClient(webapi.NewConfig())
+	}
+
+	return i
+}
+
+// AuthorizeURL returns the URL of Slack's authorization page with the given state parameter.
+func (i *Installer) AuthorizeURL(state string) string {
+	values := url.Values{}
+	values.Set("client_id", i.config.ClientID)
+	values.Set("scope", strings.Join(i.config.Scopes, ","))
+	if len(i.config.UserScopes) > 0 {
+		values.Set("user_scope", strings.Join(i.config.UserScopes, ","))
+	}
+	if i.config.RedirectURL != "" {
+		values.Set("redirect_uri", i.config.RedirectURL)
+	}
+	values.Set("state", state)
+	return fmt.Sprintf("%s?%s", authorizeURL, values.Encode())
+}
+
+// InstallHandler returns http.HandlerFunc that redirects the user to Slack's authorization page.
+// A newly issued state parameter is stored in a cookie so CallbackHandler can verify that the callback is initiated by the same browser.
+func (i *Installer) InstallHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		state, err := i.issueState()
+		if err != nil {
+			i.onFailure(w, r, err)
+			return
+		}
+
+		http.SetCookie(w, &http.Cookie{
+			Name:     i.config.StateCookieName,
+			Value:    state,
+			Path:     "/",
+			MaxAge:   int(i.config.StateTTL / time.Second),
+			Secure:   i.secureCookie(r),
+			HttpOnly: true,
+
+			// Lax is required since the callback is a top-level navigation from slack.com
+			SameSite: http.SameSiteLaxMode,
+		})
+		http.Redirect(w, r, i.AuthorizeURL(state), http.StatusFound)
+	}
+}
+
+// CallbackHandler returns http.HandlerFunc that serves the redirect URL.
+// This verifies the state parameter, exchanges the code for tokens with oauth.v2.access method and saves the installation.
+func (i *Installer) CallbackHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// The state is for one-time use
+		http.SetCookie(w, &http.Cookie{
+			Name:     i.config.StateCookieName,
+			Value:    "",
+			Path:     "/",
+			MaxAge:   -1,
+			Secure:   i.secureCookie(r),
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		})
+
+		query := r.URL.Query()
+		err := i.verifyState(r, query.Get("state"))
+		if err != nil {
+			i.onFailure(w, r, err)
+			return
+		}
+
+		if code := query.Get("error"); code != "" {
+			i.onFailure(w, r, fmt.Errorf("%w: %s", ErrAuthorizationDenied, code))
+			return
+		}
+
+		code := query.Get("code")
+		if code == "" {
+			i.onFailure(w, r, ErrMissingCode)
+			return
+		}
+
+		installation, err := i.exchange(r.Context(), code)
+		if err != nil {
+			i.onFailure(w, r, err)
+			return
+		}
+
+		err = i.store.Save(r.Context(), installation)
+		if err != nil {
+			i.onFailure(w, r, fmt.Errorf("failed to save installation: %w", err))
+			return
+		}
+
+		i.onSuccess(w, r, installation)
+	}
+}
+
+func (i *Installer) exchange(ctx context.Context, code string) (*Installation, error) {
+	req := webapi.NewOAuthV2Access(i.config.ClientID, i.config.ClientSecret, code).WithRedirectURI(i.config.RedirectURL)
+	response := &webapi.OAuthV2AccessResponse{}
+	err := i.webClient.Post(ctx, "oauth.v2.access", req, response)
+	if err != nil {
+		return nil, err
+	}
+	err = response.Err("oauth.v2.access")
+	if err != nil {
+		return nil, err
+	}
+
+	return newInstallation(response, i.now()), nil
+}
+
+func newInstallation(response *webapi.OAuthV2AccessResponse, now time.Time) *Installation {
+	installation := &Installation{
+		AppID:               response.AppID,
+		IsEnterpriseInstall: response.IsEnterpriseInstall,
+		BotUserID:           response.BotUserID,
+		BotToken:            response.AccessToken,
+		BotScopes:           splitScopes(response.Scope),
+		BotRefreshToken:     response.RefreshToken,
+		BotTokenExpiresAt:   expiresAt(now, response.ExpiresIn),
+		InstalledAt:         now,
+	}
+
+	if response.Team != nil {
+		installation.TeamID = response.Team.ID
+		installation.TeamName = response.Team.Name
+	}
+
+	if response.Enterprise != nil {
+		installation.EnterpriseID = response.Enterprise.ID
+		installation.EnterpriseName = response.Enterprise.Name
+	}
+
+	if user := response.AuthedUser; user != nil {
+		installation.UserID = user.ID
+		installation.UserToken = user.AccessToken
+		installation.UserScopes = splitScopes(user.Scope)
+		installation.UserRefreshToken = user.RefreshToken
+		installation.UserTokenExpiresAt = expiresAt(now, user.ExpiresIn)
+	}
+
+	return installation
+}
+
+// issueState returns a new state parameter that consists of the issued time and a random nonce.
+func (i *Installer) issueState() (string, error) {
+	nonce := make([]byte, 16)
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate state: %w", err)
+	}
+	return fmt.Sprintf("%d.%s", i.now().Unix(), hex.EncodeToString(nonce)), nil
+}
+
+// verifyState checks if the given state matches the one stored in the cookie and is not expired.
+func (i *Installer) verifyState(r *http.Request, state string) error {
+	cookie, err := r.Cookie(i.config.StateCookieName)
+	if err != nil || state == "" {
+		return ErrInvalidState
+	}
+
+	if subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+		return ErrInvalidState
+	}
+
+	issuedAt, err := strconv.ParseInt(strings.SplitN(state, ".", 2)[0], 10, 64)
+	if err != nil {
+		return ErrInvalidState
+	}
+	if i.now().Sub(time.Unix(issuedAt, 0)) > i.config.StateTTL {
+		return fmt.Errorf("%w: expired", ErrInvalidState)
+	}
+
+	return nil
+}
+
+// secureCookie tells whether the state cookie should only be sent over HTTPS.
+func (i *Installer) secureCookie(r *http.Request) bool {
+	if i.config.RedirectURL != "" {
+		return strings.HasPrefix(i.config.RedirectURL, "https://")
+	}
+	return r.TLS != nil
+}
+
+func splitScopes(scope string) []string {
+	if scope == "" {
+		return nil
+	}
+	return strings.Split(scope, ",")
+}
+
+func expiresAt(now time.Time, expiresIn int) time.Time {
+	if expiresIn <= 0 {
+		return time.Time{}
+	}
+	return now.Add(time.Duration(expiresIn) * time.Second)
+}
+
+func defaultSuccessHandler(w http.ResponseWriter, _ *http.Request, _ *Installation) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write([]byte("The app is successfully installed."))
+}
+
+func defaultFailureHandler(w http.ResponseWriter, _ *http.Request, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrInvalidState), errors.Is(err, ErrMissingCode):
+		status = http.StatusBadRequest
+
+	case errors.Is(err, ErrAuthorizationDenied):
+		status = http.StatusForbidden
+
+	}
+
+	log.Printf("Failed to install the app: %s", err.Error())
+	http.Error(w, "Failed to install the app.", status)
+}
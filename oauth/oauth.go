@@ -0,0 +1,95 @@
+// Package oauth provides the OAuth v2 installation flow to distribute an app to multiple workspaces.
+// Installer serves the endpoint that redirects users to Slack's authorization page and the callback endpoint that exchanges the code for tokens,
+// and then the acquired tokens are saved to InstallationStore per workspace and Enterprise Grid organization.
+//
+//	config := oauth.NewConfig()
+//	config.ClientID = "..."
+//	config.ClientSecret = "..."
+//	config.Scopes = []string{"chat:write", "commands"}
+//	store, _ := oauth.NewFileInstallationStore("/var/lib/myapp/installations")
+//	installer := oauth.NewInstaller(config, store)
+//	http.Handle("/slack/install", installer.InstallHandler())
+//	http.Handle("/slack/oauth_redirect", installer.CallbackHandler())
+//
+// See https://api.slack.com/authentication/oauth-v2
+package oauth
+
+import (
+	"github.com/oklahomer/golack/v2/event"
+	"time"
+)
+
+const (
+	authorizeURL = "https://slack.com/oauth/v2/authorize"
+)
+
+// Config defines the serializable/deserializable configuration value.
+// Use NewConfig() to build a Config with default setting, and then pass the instance to json.Unmarshal()/yaml.Unmarshal() to apply customization.
+type Config struct {
+	ClientID     string `json:"client_id" yaml:"client_id"`
+	ClientSecret string `json:"client_secret" yaml:"client_secret"`
+
+	// Scopes and UserScopes are the scopes requested for the bot token and the user token respectively.
+	Scopes     []string `json:"scopes" yaml:"scopes"`
+	UserScopes []string `json:"user_scopes" yaml:"user_scopes"`
+
+	// RedirectURL is the URL of the callback endpoint.
+	// When this is empty, the redirect URL configured on the app management page is used.
+	RedirectURL string `json:"redirect_url" yaml:"redirect_url"`
+
+	// StateCookieName and StateTTL define the cookie that binds the state parameter to the user's browser.
+	StateCookieName string        `json:"state_cookie_name" yaml:"state_cookie_name"`
+	StateTTL        time.Duration `json:"state_ttl" yaml:"state_ttl"`
+}
+
+// NewConfig returns initialized Config struct with default settings.
+// ClientID and ClientSecret are empty at this point. They can be set/updated by feeding this instance to json.Unmarshal/yaml.Unmarshal
+// or by direct assignment.
+func NewConfig() *Config {
+	return &Config{
+		ClientID:        "",
+		ClientSecret:    "",
+		Scopes:          []string{},
+		UserScopes:      []string{},
+		RedirectURL:     "",
+		StateCookieName: "slack_oauth_state",
+		StateTTL:        10 * time.Minute,
+	}
+}
+
+// Installation represents the tokens acquired by installing the app to a workspace or an Enterprise Grid organization.
+// For an organization-wide installation, IsEnterpriseInstall is true and TeamID is empty.
+type Installation struct {
+	AppID               event.AppID  `json:"app_id"`
+	EnterpriseID        string       `json:"enterprise_id,omitempty"`
+	EnterpriseName      string       `json:"enterprise_name,omitempty"`
+	TeamID              event.TeamID `json:"team_id,omitempty"`
+	TeamName            string       `json:"team_name,omitempty"`
+	IsEnterpriseInstall bool         `json:"is_enterprise_install"`
+
+	BotUserID       event.UserID `json:"bot_user_id,omitempty"`
+	BotToken        string       `json:"bot_token,omitempty"`
+	BotScopes       []string     `json:"bot_scopes,omitempty"`
+	BotRefreshToken string       `json:"bot_refresh_token,omitempty"`
+
+	// BotTokenExpiresAt is zero unless token rotation is enabled.
+	BotTokenExpiresAt time.Time `json:"bot_token_expires_at,omitempty"`
+
+	// UserID is the user who installed the app. UserToken is only given when user scopes are requested.
+	UserID           event.UserID `json:"user_id,omitempty"`
+	UserToken        string       `json:"user_token,omitempty"`
+	UserScopes       []string     `json:"user_scopes,omitempty"`
+	UserRefreshToken string       `json:"user_refresh_token,omitempty"`
+
+	// UserTokenExpiresAt is zero unless token rotation is enabled.
+	UserTokenExpiresAt time.Time `json:"user_token_expires_at,omitempty"`
+
+	InstalledAt time.Time `json:"installed_at"`
+}
+
+func (i *Installation) copy() *Installation {
+	copied := *i
+	copied.BotScopes = append([]string(nil), i.BotScopes...)
+	copied.UserScopes = append([]string(nil), i.UserScopes...)
+	return &copied
+}
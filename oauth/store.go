@@ -0,0 +1,101 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"github.com/oklahomer/golack/v2/event"
+	"sync"
+)
+
+// ErrInstallationNotFound is returned by InstallationStore when no installation is saved for the given workspace.
+var ErrInstallationNotFound = errors.New("installation not found")
+
+// InstallationStore defines an interface to persist the installations.
+// Implementations must be safe for concurrent use.
+//
+// An organization-wide installation is saved with an empty team ID, and Find falls back to it
+// when no installation is saved for the given workspace in the organization.
+type InstallationStore interface {
+	// Save saves the given installation. An existing installation for the same workspace or organization is overwritten.
+	Save(ctx context.Context, installation *Installation) error
+
+	// Find returns the installation for the given workspace.
+	// Give an empty enterpriseID for a workspace that does not belong to an Enterprise Grid organization.
+	// ErrInstallationNotFound is returned when nothing is saved.
+	Find(ctx context.Context, enterpriseID string, teamID event.TeamID) (*Installation, error)
+
+	// Delete deletes the installation for the given workspace or organization.
+	// Deleting a missing installation is not an error.
+	Delete(ctx context.Context, enterpriseID string, teamID event.TeamID) error
+}
+
+type installationKey struct {
+	enterpriseID string
+	teamID       event.TeamID
+}
+
+func keyOf(installation *Installation) installationKey {
+	if installation.IsEnterpriseInstall {
+		return installationKey{enterpriseID: installation.EnterpriseID}
+	}
+	return installationKey{enterpriseID: installation.EnterpriseID, teamID: installation.TeamID}
+}
+
+// find looks up the installation for the given workspace, and then the organization-wide installation.
+func find(enterpriseID string, teamID event.TeamID, lookup func(installationKey) (*Installation, error)) (*Installation, error) {
+	installation, err := lookup(installationKey{enterpriseID: enterpriseID, teamID: teamID})
+	if err == nil || !errors.Is(err, ErrInstallationNotFound) || enterpriseID == "" || teamID == "" {
+		return installation, err
+	}
+
+	return lookup(installationKey{enterpriseID: enterpriseID})
+}
+
+// MemoryInstallationStore is an InstallationStore implementation that keeps installations in memory.
+// This is useful for development and testing since the installations are lost on restart.
+type MemoryInstallationStore struct {
+	installations map[installationKey]*Installation
+	mutex         *sync.RWMutex
+}
+
+var _ InstallationStore = (*MemoryInstallationStore)(nil)
+
+// NewMemoryInstallationStore creates an empty MemoryInstallationStore.
+func NewMemoryInstallationStore() *MemoryInstallationStore {
+	return &MemoryInstallationStore{
+		installations: map[installationKey]*Installation{},
+		mutex:         &sync.RWMutex{},
+	}
+}
+
+// Save saves a copy of the given installation.
+func (s *MemoryInstallationStore) Save(_ context.Context, installation *Installation) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.installations[keyOf(installation)] = installation.copy()
+	return nil
+}
+
+// Find returns a copy of the saved installation.
+func (s *MemoryInstallationStore) Find(_ context.Context, enterpriseID string, teamID event.TeamID) (*Installation, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return find(enterpriseID, teamID, func(key installationKey) (*Installation, error) {
+		installation, ok := s.installations[key]
+		if !ok {
+			return nil, ErrInstallationNotFound
+		}
+		return installation.copy(), nil
+	})
+}
+
+// Delete deletes the installation.
+func (s *MemoryInstallationStore) Delete(_ context.Context, enterpriseID string, teamID event.TeamID) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.installations, installationKey{enterpriseID: enterpriseID, teamID: teamID})
+	return nil
+}
@@ -0,0 +1,142 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func testInstallationStore(t *testing.T, store InstallationStore) {
+	ctx := context.TODO()
+
+	_, err := store.Find(ctx, "", "T123")
+	if !errors.Is(err, ErrInstallationNotFound) {
+		t.Fatalf("Expected error is not returned: %#v.", err)
+	}
+
+	workspace := &Installation{
+		TeamID:      "T123",
+		BotToken:    "xoxb-1",
+		BotScopes:   []string{"commands"},
+		InstalledAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	err = store.Save(ctx, workspace)
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+
+	// Modifying the saved installation must not affect the stored one
+	workspace.BotScopes[0] = "modified"
+
+	found, err := store.Find(ctx, "", "T123")
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+	if found.BotToken != "xoxb-1" || found.BotScopes[0] != "commands" || !found.InstalledAt.Equal(workspace.InstalledAt) {
+		t.Errorf("Unexpected installation is returned: %#v.", found)
+	}
+
+	// Organization-wide installation is found for any workspace in the organization
+	org := &Installation{
+		EnterpriseID:        "E123",
+		TeamID:              "",
+		IsEnterpriseInstall: true,
+		BotToken:            "xoxb-org",
+	}
+	err = store.Save(ctx, org)
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+
+	found, err = store.Find(ctx, "E123", "T456")
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+	if found.BotToken != "xoxb-org" {
+		t.Errorf("Unexpected installation is returned: %#v.", found)
+	}
+
+	// Workspace-level installation in the organization takes precedence
+	err = store.Save(ctx, &Installation{EnterpriseID: "E123", TeamID: "T456", BotToken: "xoxb-2"})
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+	found, err = store.Find(ctx, "E123", "T456")
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+	if found.BotToken != "xoxb-2" {
+		t.Errorf("Unexpected installation is returned: %#v.", found)
+	}
+
+	err = store.Delete(ctx, "", "T123")
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+	_, err = store.Find(ctx, "", "T123")
+	if !errors.Is(err, ErrInstallationNotFound) {
+		t.Errorf("Installation is not deleted: %#v.", err)
+	}
+
+	err = store.Delete(ctx, "", "T123")
+	if err != nil {
+		t.Errorf("Deleting a missing installation must not fail: %s.", err.Error())
+	}
+}
+
+func TestMemoryInstallationStore(t *testing.T) {
+	testInstallationStore(t, NewMemoryInstallationStore())
+}
+
+func TestFileInstallationStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "installations")
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	store, err := NewFileInstallationStore(filepath.Join(dir, "store"))
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+
+	testInstallationStore(t, store)
+
+	info, err := os.Stat(filepath.Join(dir, "store", "E123-none.json"))
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Unexpected permission is set: %s.", info.Mode())
+	}
+
+	files, err := ioutil.ReadDir(filepath.Join(dir, "store"))
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+	if len(files) != 2 {
+		t.Errorf("Unexpected files remain: %d.", len(files))
+	}
+}
+
+func TestFileInstallationStore_InvalidID(t *testing.T) {
+	dir, err := ioutil.TempDir("", "installations")
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	store, err := NewFileInstallationStore(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+
+	_, err = store.Find(context.TODO(), "", "../T123")
+	if err == nil || errors.Is(err, ErrInstallationNotFound) {
+		t.Errorf("Expected error is not returned: %#v.", err)
+	}
+}
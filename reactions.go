@@ -0,0 +1,121 @@
+package golack
+
+import (
+	"context"
+	"github.com/oklahomer/golack/v2/event"
+	"github.com/oklahomer/golack/v2/webapi"
+	"net/url"
+)
+
+// AddReaction adds a reaction with the given emoji name such as "eyes" to the given item.
+// To acknowledge an incoming message, pass webapi.NewMessageItem with the message's ChannelID and TimeStamp.
+//
+// See https://api.slack.com/methods/reactions.add for official document.
+func (g *Golack) AddReaction(ctx context.Context, item *webapi.ItemRef, name string) (*webapi.APIResponse, error) {
+	response := &webapi.APIResponse{}
+	err := g.post(ctx, "reactions.add", webapi.NewAddReaction(item, name), response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// RemoveReaction removes a reaction with the given emoji name from the given item.
+//
+// See https://api.slack.com/methods/reactions.remove for official document.
+func (g *Golack) RemoveReaction(ctx context.Context, item *webapi.ItemRef, name string) (*webapi.APIResponse, error) {
+	response := &webapi.APIResponse{}
+	err := g.post(ctx, "reactions.remove", webapi.NewRemoveReaction(item, name), response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetReactions retrieves the reactions attached to the given item.
+//
+// See https://api.slack.com/methods/reactions.get for official document.
+func (g *Golack) GetReactions(ctx context.Context, item *webapi.ItemRef, full bool) (*webapi.ItemResponse, error) {
+	values := item.ToURLValues()
+	if full {
+		values.Set("full", "true")
+	}
+	response := &webapi.ItemResponse{}
+	err := g.get(ctx, "reactions.get", values, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// ListReactions fetches a page of items reacted by a user.
+//
+// See https://api.slack.com/methods/reactions.list for official document.
+func (g *Golack) ListReactions(ctx context.Context, req *webapi.ReactionsListRequest) (*webapi.ItemsResponse, error) {
+	response := &webapi.ItemsResponse{}
+	err := g.get(ctx, "reactions.list", req.ToURLValues(), response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// AddPin pins the given message to its channel.
+//
+// See https://api.slack.com/methods/pins.add for official document.
+func (g *Golack) AddPin(ctx context.Context, item *webapi.ItemRef) (*webapi.APIResponse, error) {
+	response := &webapi.APIResponse{}
+	err := g.post(ctx, "pins.add", item, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// RemovePin un-pins the given message from its channel.
+//
+// See https://api.slack.com/methods/pins.remove for official document.
+func (g *Golack) RemovePin(ctx context.Context, item *webapi.ItemRef) (*webapi.APIResponse, error) {
+	response := &webapi.APIResponse{}
+	err := g.post(ctx, "pins.remove", item, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// ListPins lists items pinned to the given channel.
+//
+// See https://api.slack.com/methods/pins.list for official document.
+func (g *Golack) ListPins(ctx context.Context, channelID event.ChannelID) (*webapi.ItemsResponse, error) {
+	response := &webapi.ItemsResponse{}
+	err := g.get(ctx, "pins.list", url.Values{"channel": []string{channelID.String()}}, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// AddStar stars the given item.
+//
+// See https://api.slack.com/methods/stars.add for official document.
+func (g *Golack) AddStar(ctx context.Context, item *webapi.ItemRef) (*webapi.APIResponse, error) {
+	response := &webapi.APIResponse{}
+	err := g.post(ctx, "stars.add", item, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// RemoveStar removes a star from the given item.
+//
+// See https://api.slack.com/methods/stars.remove for official document.
+func (g *Golack) RemoveStar(ctx context.Context, item *webapi.ItemRef) (*webapi.APIResponse, error) {
+	response := &webapi.APIResponse{}
+	err := g.post(ctx, "stars.remove", item, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
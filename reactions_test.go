@@ -0,0 +1,513 @@
+package golack
+
+import (
+	"context"
+	"errors"
+	"github.com/oklahomer/golack/v2/event"
+	"github.com/oklahomer/golack/v2/webapi"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestGolack_AddReaction(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		item := webapi.NewMessageItem("C123", &event.TimeStamp{OriginalValue: "1.0"})
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.APIResponse)
+				resp.OK = false
+				resp.Error = "already_reacted"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.AddReaction(context.TODO(), item, "eyes")
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "reactions.add" || apiErr.Code != "already_reacted" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		item := webapi.NewMessageItem("C123", &event.TimeStamp{OriginalValue: "1.0"})
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "reactions.add" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				expected := &webapi.AddReaction{ItemRef: item, Name: "eyes"}
+				if !reflect.DeepEqual(payload, expected) {
+					t.Errorf("Unexpected payload is given: %#v.", payload)
+				}
+
+				response.(*webapi.APIResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.AddReaction(context.TODO(), item, "eyes")
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_RemoveReaction(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		item := webapi.NewMessageItem("C123", &event.TimeStamp{OriginalValue: "1.0"})
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.APIResponse)
+				resp.OK = false
+				resp.Error = "no_reaction"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.RemoveReaction(context.TODO(), item, "eyes")
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "reactions.remove" || apiErr.Code != "no_reaction" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		item := webapi.NewMessageItem("C123", &event.TimeStamp{OriginalValue: "1.0"})
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "reactions.remove" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				expected := &webapi.RemoveReaction{ItemRef: item, Name: "eyes"}
+				if !reflect.DeepEqual(payload, expected) {
+					t.Errorf("Unexpected payload is given: %#v.", payload)
+				}
+
+				response.(*webapi.APIResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.RemoveReaction(context.TODO(), item, "eyes")
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_GetReactions(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		item := webapi.NewMessageItem("C123", &event.TimeStamp{OriginalValue: "1.0"})
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, _ string, _ url.Values, response interface{}) error {
+				resp := response.(*webapi.ItemResponse)
+				resp.OK = false
+				resp.Error = "message_not_found"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.GetReactions(context.TODO(), item, true)
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "reactions.get" || apiErr.Code != "message_not_found" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		item := webapi.NewMessageItem("C123", &event.TimeStamp{OriginalValue: "1.0"})
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, slackMethod string, queryParams url.Values, response interface{}) error {
+				if slackMethod != "reactions.get" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if queryParams.Encode() != "channel=C123&full=true&timestamp=1.0" {
+					t.Errorf("Unexpected query parameters are given: %s.", queryParams.Encode())
+				}
+
+				response.(*webapi.ItemResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.GetReactions(context.TODO(), item, true)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_ListReactions(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, _ string, _ url.Values, response interface{}) error {
+				resp := response.(*webapi.ItemsResponse)
+				resp.OK = false
+				resp.Error = "user_not_found"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.ListReactions(context.TODO(), webapi.NewReactionsListRequest().WithUserID("U123"))
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "reactions.list" || apiErr.Code != "user_not_found" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, slackMethod string, queryParams url.Values, response interface{}) error {
+				if slackMethod != "reactions.list" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if queryParams.Encode() != "user=U123" {
+					t.Errorf("Unexpected query parameters are given: %s.", queryParams.Encode())
+				}
+
+				response.(*webapi.ItemsResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.ListReactions(context.TODO(), webapi.NewReactionsListRequest().WithUserID("U123"))
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_AddPin(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		item := webapi.NewMessageItem("C123", &event.TimeStamp{OriginalValue: "1.0"})
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.APIResponse)
+				resp.OK = false
+				resp.Error = "already_pinned"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.AddPin(context.TODO(), item)
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "pins.add" || apiErr.Code != "already_pinned" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		item := webapi.NewMessageItem("C123", &event.TimeStamp{OriginalValue: "1.0"})
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "pins.add" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if payload != item {
+					t.Errorf("Given payload is not passed: %#v.", payload)
+				}
+
+				response.(*webapi.APIResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.AddPin(context.TODO(), item)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_RemovePin(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		item := webapi.NewMessageItem("C123", &event.TimeStamp{OriginalValue: "1.0"})
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.APIResponse)
+				resp.OK = false
+				resp.Error = "no_pin"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.RemovePin(context.TODO(), item)
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "pins.remove" || apiErr.Code != "no_pin" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		item := webapi.NewMessageItem("C123", &event.TimeStamp{OriginalValue: "1.0"})
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "pins.remove" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if payload != item {
+					t.Errorf("Given payload is not passed: %#v.", payload)
+				}
+
+				response.(*webapi.APIResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.RemovePin(context.TODO(), item)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_ListPins(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, _ string, _ url.Values, response interface{}) error {
+				resp := response.(*webapi.ItemsResponse)
+				resp.OK = false
+				resp.Error = "channel_not_found"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.ListPins(context.TODO(), "C123")
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "pins.list" || apiErr.Code != "channel_not_found" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, slackMethod string, queryParams url.Values, response interface{}) error {
+				if slackMethod != "pins.list" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if queryParams.Encode() != "channel=C123" {
+					t.Errorf("Unexpected query parameters are given: %s.", queryParams.Encode())
+				}
+
+				response.(*webapi.ItemsResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.ListPins(context.TODO(), "C123")
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_AddStar(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		item := webapi.NewMessageItem("C123", &event.TimeStamp{OriginalValue: "1.0"})
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.APIResponse)
+				resp.OK = false
+				resp.Error = "already_starred"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.AddStar(context.TODO(), item)
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "stars.add" || apiErr.Code != "already_starred" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		item := webapi.NewMessageItem("C123", &event.TimeStamp{OriginalValue: "1.0"})
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "stars.add" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if payload != item {
+					t.Errorf("Given payload is not passed: %#v.", payload)
+				}
+
+				response.(*webapi.APIResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.AddStar(context.TODO(), item)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_RemoveStar(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		item := webapi.NewMessageItem("C123", &event.TimeStamp{OriginalValue: "1.0"})
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.APIResponse)
+				resp.OK = false
+				resp.Error = "not_starred"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.RemoveStar(context.TODO(), item)
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "stars.remove" || apiErr.Code != "not_starred" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		item := webapi.NewMessageItem("C123", &event.TimeStamp{OriginalValue: "1.0"})
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "stars.remove" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if payload != item {
+					t.Errorf("Given payload is not passed: %#v.", payload)
+				}
+
+				response.(*webapi.APIResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.RemoveStar(context.TODO(), item)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
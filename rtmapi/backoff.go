@@ -0,0 +1,34 @@
+package rtmapi
+
+import (
+	"github.com/oklahomer/golack/v2/internal/backoff"
+	"time"
+)
+
+// Backoff calculates the interval to wait before the next connection attempt.
+// The interval grows exponentially by Multiplier until it reaches Max, and is randomized by Jitter.
+type Backoff struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+
+	// Jitter is the ratio, between 0 and 1, of the interval that is randomized.
+	// e.g. With the value of 0.2, a 10 seconds interval becomes a random duration between 8 and 12 seconds.
+	Jitter float64
+}
+
+// NewBackoff returns Backoff with default settings.
+func NewBackoff() *Backoff {
+	return &Backoff{
+		Initial:    1 * time.Second,
+		Max:        1 * time.Minute,
+		Multiplier: 2,
+		Jitter:     0.2,
+	}
+}
+
+// Duration returns the interval to wait before the given attempt.
+// The attempt starts from 0.
+func (b *Backoff) Duration(attempt int) time.Duration {
+	return backoff.Duration(b.Initial, b.Max, b.Multiplier, b.Jitter, attempt)
+}
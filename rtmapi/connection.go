@@ -3,12 +3,37 @@ package rtmapi
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/gorilla/websocket"
 	"github.com/oklahomer/golack/v2/event"
 	"github.com/tidwall/gjson"
 	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrPongTimeout is returned when the connection is considered dead because pong events are not returned for consecutive pings.
+	ErrPongTimeout = errors.New("pong is not returned for consecutive pings")
+
+	// ErrQueueFull is returned when the outgoing queue is full and QueueFullError policy is set.
+	ErrQueueFull = errors.New("outgoing queue is full")
+)
+
+// QueueFullPolicy decides how to handle a payload to be sent when the outgoing queue is full.
+type QueueFullPolicy int
+
+const (
+	// QueueFullBlock blocks the sender until the queue has room or the connection is closed.
+	QueueFullBlock QueueFullPolicy = iota
+
+	// QueueFullError drops the payload and immediately returns ErrQueueFull to the sender.
+	QueueFullError
 )
 
 type UnexpectedMessageTypeError struct {
@@ -27,7 +52,13 @@ type PayloadReceiver interface {
 }
 
 type PayloadSender interface {
-	Send(*OutgoingMessage) error
+	// Send sends the given payload such as *OutgoingMessage, *Typing and *PresenceSubscribe.
+	// The ID of the payload is assigned on sending.
+	Send(OutgoingPayload) error
+
+	// SendWithReply sends the given message and returns PendingReply to receive the corresponding reply.
+	SendWithReply(*OutgoingMessage) (*PendingReply, error)
+
 	Ping() error
 }
 
@@ -37,14 +68,164 @@ type Connection interface {
 	io.Closer
 }
 
+// LatencyReporter is implemented by Connection that measures the round-trip latency with ping and pong.
+type LatencyReporter interface {
+	// Latency returns the latest round-trip latency.
+	// Zero is returned when no pong is received yet.
+	Latency() time.Duration
+}
+
+// ConnectOption changes the behavior of the connection.
+// Pass this to Connect.
+type ConnectOption func(*connectOption)
+
+// WithKeepAlive enables a keepalive loop that sends ping on every interval.
+// When pong events are not returned for maxMissedPongs consecutive pings, the connection is closed and
+// Receive returns ErrPongTimeout.
+func WithKeepAlive(interval time.Duration, maxMissedPongs int) ConnectOption {
+	return func(o *connectOption) {
+		o.keepAliveInterval = interval
+		o.maxMissedPongs = maxMissedPongs
+	}
+}
+
+// WithLatencyHandler sets a function that is called with the round-trip latency every time a pong for a sent ping is received.
+func WithLatencyHandler(fnc func(time.Duration)) ConnectOption {
+	return func(o *connectOption) {
+		o.latencyHandler = fnc
+	}
+}
+
+// WithOutgoingQueue sets the size of the outgoing queue and the policy to apply when the queue is full.
+// All outgoing payloads including pings from keepalive loop are written by a dedicated writer goroutine in the queued order
+// because the underlying WebSocket connection supports only one concurrent writer.
+// By default, the size is 100 and QueueFullBlock is applied.
+func WithOutgoingQueue(size int, policy QueueFullPolicy) ConnectOption {
+	return func(o *connectOption) {
+		o.queueSize = size
+		o.queueFullPolicy = policy
+	}
+}
+
+// WithReplyTimeout sets the duration to wait for the reply of a message sent by SendWithReply.
+func WithReplyTimeout(timeout time.Duration) ConnectOption {
+	return func(o *connectOption) {
+		o.replyTimeout = timeout
+	}
+}
+
+// WithDialer sets the base websocket.Dialer to establish a connection.
+// websocket.DefaultDialer is used by default.
+// Other dialer-related options such as WithProxy and WithTLSConfig override the corresponding fields of the given dialer.
+func WithDialer(dialer *websocket.Dialer) ConnectOption {
+	return func(o *connectOption) {
+		o.dialer = dialer
+	}
+}
+
+// WithProxy sets a function that returns a proxy URL for the given handshake request.
+// Pass http.ProxyURL to route through a fixed proxy, or http.ProxyFromEnvironment to respect environment variables.
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) ConnectOption {
+	return func(o *connectOption) {
+		o.proxy = proxy
+	}
+}
+
+// WithTLSConfig sets the TLS configuration to use on the handshake.
+func WithTLSConfig(config *tls.Config) ConnectOption {
+	return func(o *connectOption) {
+		o.tlsConfig = config
+	}
+}
+
+// WithHandshakeTimeout sets the duration for the handshake to complete.
+// The context given to Connect is also respected during the handshake.
+func WithHandshakeTimeout(timeout time.Duration) ConnectOption {
+	return func(o *connectOption) {
+		o.handshakeTimeout = timeout
+	}
+}
+
+// WithHeader sets additional HTTP headers to be sent on the handshake.
+func WithHeader(header http.Header) ConnectOption {
+	return func(o *connectOption) {
+		o.header = header
+	}
+}
+
+// WithCompression enables or disables per-message compression negotiation as described in RFC 7692.
+func WithCompression(enable bool) ConnectOption {
+	return func(o *connectOption) {
+		o.compression = &enable
+	}
+}
+
+type connectOption struct {
+	dialer            *websocket.Dialer
+	proxy             func(*http.Request) (*url.URL, error)
+	tlsConfig         *tls.Config
+	handshakeTimeout  time.Duration
+	header            http.Header
+	compression       *bool
+	keepAliveInterval time.Duration
+	maxMissedPongs    int
+	latencyHandler    func(time.Duration)
+	replyTimeout      time.Duration
+	queueSize         int
+	queueFullPolicy   QueueFullPolicy
+}
+
+func newConnectOption() *connectOption {
+	return &connectOption{
+		dialer:            websocket.DefaultDialer,
+		keepAliveInterval: 0,
+		maxMissedPongs:    3,
+		replyTimeout:      10 * time.Second,
+		queueSize:         100,
+		queueFullPolicy:   QueueFullBlock,
+	}
+}
+
 // Connect connects to Slack WebSocket server.
-func Connect(_ context.Context, url string) (Connection, error) {
-	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+// The given ctx is respected while the connection is being established, so a hung dial or handshake can be canceled.
+func Connect(ctx context.Context, url string, options ...ConnectOption) (Connection, error) {
+	opt := newConnectOption()
+	for _, o := range options {
+		o(opt)
+	}
+
+	conn, _, err := opt.buildDialer().DialContext(ctx, url, opt.header)
 	if err != nil {
 		return nil, err
 	}
 
-	return newConnectionWrapper(conn), nil
+	return newConnectionWrapper(conn, opt), nil
+}
+
+// buildDialer returns a copy of the base dialer with the given options applied so the base dialer is never modified.
+func (o *connectOption) buildDialer() *websocket.Dialer {
+	dialer := &websocket.Dialer{}
+	if o.dialer != nil {
+		*dialer = *o.dialer
+	}
+
+	if o.proxy != nil {
+		dialer.Proxy = o.proxy
+	}
+
+	if o.tlsConfig != nil {
+		dialer.TLSClientConfig = o.tlsConfig
+	}
+
+	if o.handshakeTimeout > 0 {
+		dialer.HandshakeTimeout = o.handshakeTimeout
+	}
+
+	if o.compression != nil {
+		dialer.EnableCompression = *o.compression
+	}
+
+	return dialer
 }
 
 // connWrapper is a thin wrapper that wraps WebSocket connection and its methods.
@@ -55,14 +236,53 @@ type connWrapper struct {
 	// https://api.slack.com/rtm#sending_messages
 	// Every event should have a unique (for that connection) positive integer ID.
 	outgoingEventID *OutgoingEventID
+
+	// gorilla/websocket supports only one concurrent writer.
+	// Every outgoing payload is passed to the dedicated writer goroutine through this queue.
+	outgoing        chan *writeRequest
+	queueFullPolicy QueueFullPolicy
+
+	latencyHandler func(time.Duration)
+
+	// replies correlates sent messages with the replies from Slack.
+	replies      *replyRegistry
+	replyTimeout time.Duration
+
+	// Below fields track sent pings to match with returning pongs.
+	pingMutex   *sync.Mutex
+	pendingPing map[uint]time.Time
+	missedPongs int
+	latency     time.Duration
+	dead        bool
+
+	closeOnce *sync.Once
+	closed    chan struct{}
 }
 
-func newConnectionWrapper(conn *websocket.Conn) Connection {
-	return &connWrapper{
+var _ LatencyReporter = (*connWrapper)(nil)
+
+func newConnectionWrapper(conn *websocket.Conn, opt *connectOption) Connection {
+	wrapper := &connWrapper{
 		conn:            conn,
 		outgoingEventID: NewOutgoingEventID(),
+		outgoing:        make(chan *writeRequest, opt.queueSize),
+		queueFullPolicy: opt.queueFullPolicy,
+		latencyHandler:  opt.latencyHandler,
+		replies:         newReplyRegistry(),
+		replyTimeout:    opt.replyTimeout,
+		pingMutex:       &sync.Mutex{},
+		pendingPing:     map[uint]time.Time{},
+		closeOnce:       &sync.Once{},
+		closed:          make(chan struct{}),
 	}
 
+	go wrapper.writeLoop()
+
+	if opt.keepAliveInterval > 0 {
+		go wrapper.keepAlive(opt.keepAliveInterval, opt.maxMissedPongs)
+	}
+
+	return wrapper
 }
 
 // Receive is a blocking method to receive payload from WebSocket connection.
@@ -70,6 +290,9 @@ func newConnectionWrapper(conn *websocket.Conn) Connection {
 func (wrapper *connWrapper) Receive() (DecodedPayload, error) {
 	messageType, payload, err := wrapper.conn.ReadMessage()
 	if err != nil {
+		if wrapper.isDead() {
+			return nil, ErrPongTimeout
+		}
 		return nil, err
 	}
 
@@ -79,23 +302,195 @@ func (wrapper *connWrapper) Receive() (DecodedPayload, error) {
 	}
 
 	decoded, err := decodePayload(payload)
+	switch typed := decoded.(type) {
+	case *Pong:
+		wrapper.receivePong(typed)
+
+	case *OKReply, *NGReply:
+		wrapper.replies.receive(typed)
+
+	}
 	return decoded, err
 }
 
-func (wrapper *connWrapper) Send(message *OutgoingMessage) error {
+func (wrapper *connWrapper) Send(payload OutgoingPayload) error {
 	// ID must be unique per connection.
 	// Manage this value at here.
+	payload.outgoingEvent().ID = wrapper.outgoingEventID.Next()
+	return wrapper.write(payload)
+}
+
+// SendWithReply sends the given message and returns PendingReply to receive the corresponding reply.
+// The reply is passed to PendingReply while Receive is called, so keep calling Receive in another goroutine.
+func (wrapper *connWrapper) SendWithReply(message *OutgoingMessage) (*PendingReply, error) {
 	message.ID = wrapper.outgoingEventID.Next()
-	return wrapper.conn.WriteJSON(message)
+
+	// Register before sending so a quick reply is not missed
+	reply := wrapper.replies.register(message.ID, wrapper.replyTimeout)
+	err := wrapper.write(message)
+	if err != nil {
+		wrapper.replies.resolve(message.ID, nil, err)
+		return nil, err
+	}
+
+	return reply, nil
 }
 
 func (wrapper *connWrapper) Ping() error {
 	ping := NewPing(wrapper.outgoingEventID)
-	return wrapper.conn.WriteJSON(ping)
+
+	wrapper.pingMutex.Lock()
+	wrapper.pendingPing[ping.ID] = time.Now()
+	wrapper.pingMutex.Unlock()
+
+	return wrapper.write(ping)
+}
+
+// Latency returns the round-trip latency measured by the latest pair of ping and pong.
+func (wrapper *connWrapper) Latency() time.Duration {
+	wrapper.pingMutex.Lock()
+	defer wrapper.pingMutex.Unlock()
+	return wrapper.latency
 }
 
 func (wrapper *connWrapper) Close() error {
-	return wrapper.conn.Close()
+	err := wrapper.conn.Close()
+	wrapper.closeOnce.Do(func() {
+		close(wrapper.closed)
+		wrapper.replies.closeAll(ErrConnectionClosed)
+	})
+	return err
+}
+
+type writeRequest struct {
+	payload interface{}
+	result  chan error
+}
+
+// write passes the given payload to the writer goroutine and waits until it is written.
+func (wrapper *connWrapper) write(v interface{}) error {
+	req := &writeRequest{
+		payload: v,
+		result:  make(chan error, 1),
+	}
+
+	select {
+	case <-wrapper.closed:
+		return ErrConnectionClosed
+
+	default:
+		// Continue
+
+	}
+
+	if wrapper.queueFullPolicy == QueueFullError {
+		select {
+		case wrapper.outgoing <- req:
+			// O.K.
+
+		default:
+			return ErrQueueFull
+
+		}
+	} else {
+		select {
+		case wrapper.outgoing <- req:
+			// O.K.
+
+		case <-wrapper.closed:
+			return ErrConnectionClosed
+
+		}
+	}
+
+	select {
+	case err := <-req.result:
+		return err
+
+	case <-wrapper.closed:
+		return ErrConnectionClosed
+
+	}
+}
+
+// writeLoop is the only goroutine that writes to the underlying connection.
+func (wrapper *connWrapper) writeLoop() {
+	for {
+		select {
+		case req := <-wrapper.outgoing:
+			req.result <- wrapper.conn.WriteJSON(req.payload)
+
+		case <-wrapper.closed:
+			return
+
+		}
+	}
+}
+
+func (wrapper *connWrapper) receivePong(pong *Pong) {
+	wrapper.pingMutex.Lock()
+	sentAt, ok := wrapper.pendingPing[pong.ReplyTo]
+	if !ok {
+		// Not a reply to the ping sent by this connection
+		wrapper.pingMutex.Unlock()
+		return
+	}
+
+	// A pong for a later ping also proves the earlier pings are no longer worth waiting for
+	for id := range wrapper.pendingPing {
+		if id <= pong.ReplyTo {
+			delete(wrapper.pendingPing, id)
+		}
+	}
+	latency := time.Since(sentAt)
+	wrapper.latency = latency
+	wrapper.missedPongs = 0
+	wrapper.pingMutex.Unlock()
+
+	if wrapper.latencyHandler != nil {
+		wrapper.latencyHandler(latency)
+	}
+}
+
+func (wrapper *connWrapper) keepAlive(interval time.Duration, maxMissedPongs int) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			wrapper.pingMutex.Lock()
+			missed := wrapper.missedPongs
+			if missed >= maxMissedPongs {
+				wrapper.dead = true
+			}
+			wrapper.missedPongs++
+			wrapper.pingMutex.Unlock()
+
+			if missed >= maxMissedPongs {
+				// Close the connection so the blocking Receive call returns ErrPongTimeout
+				//noinspection ALL
+				wrapper.Close()
+				return
+			}
+
+			err := wrapper.Ping()
+			if err != nil {
+				// Connection is already closed. Let Receive report the error.
+				return
+			}
+
+		case <-wrapper.closed:
+			return
+
+		}
+	}
+}
+
+func (wrapper *connWrapper) isDead() bool {
+	wrapper.pingMutex.Lock()
+	defer wrapper.pingMutex.Unlock()
+	return wrapper.dead
 }
 
 func decodePayload(input json.RawMessage) (DecodedPayload, error) {
@@ -2,14 +2,18 @@ package rtmapi
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"github.com/gorilla/websocket"
 	"github.com/oklahomer/golack/v2/event"
 	"github.com/oklahomer/golack/v2/testutil"
 	"net"
+	"net/http"
+	neturl "net/url"
 	"reflect"
 	"strconv"
+	"sync"
 	"testing"
 	"time"
 )
@@ -45,8 +49,121 @@ func TestConnect_Fail(t *testing.T) {
 	})
 }
 
+func TestConnect_Canceled(t *testing.T) {
+	// A server that accepts TCP connections but never completes the handshake
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %s.", err.Error())
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := Connect(ctx, fmt.Sprintf("ws://%s", listener.Addr()))
+		errCh <- err
+	}()
+
+	select {
+	case err := <-errCh:
+		if err == nil {
+			t.Fatal("Expected error is not returned.")
+		}
+
+	case <-time.NewTimer(3 * time.Second).C:
+		t.Fatal("Connect is not canceled.")
+
+	}
+}
+
+func TestConnect_Options(t *testing.T) {
+	testutil.RunWithWebSocket(func(addr net.Addr) {
+		url := fmt.Sprintf("ws://%s%s", addr, "/echo")
+		header := http.Header{}
+		header.Set("X-Custom-Header", "value")
+		var requested *http.Request
+		conn, err := Connect(
+			context.TODO(),
+			url,
+			WithHeader(header),
+			WithProxy(func(req *http.Request) (*neturl.URL, error) {
+				// Do not actually route through a proxy, but see the handshake request
+				requested = req
+				return nil, nil
+			}),
+			WithCompression(true),
+		)
+		if err != nil {
+			t.Fatalf("webSocket connection error: %s.", err.Error())
+		}
+		defer conn.Close()
+
+		if requested == nil {
+			t.Fatal("Proxy function is not called.")
+		}
+
+		if requested.Header.Get("X-Custom-Header") != "value" {
+			t.Errorf("Expected header is not sent: %#v.", requested.Header)
+		}
+	})
+}
+
+func Test_connectOption_buildDialer(t *testing.T) {
+	base := &websocket.Dialer{
+		HandshakeTimeout: 3 * time.Second,
+		ReadBufferSize:   123,
+	}
+	tlsConfig := &tls.Config{}
+	opt := newConnectOption()
+	for _, o := range []ConnectOption{
+		WithDialer(base),
+		WithTLSConfig(tlsConfig),
+		WithHandshakeTimeout(5 * time.Second),
+		WithCompression(true),
+	} {
+		o(opt)
+	}
+
+	dialer := opt.buildDialer()
+
+	if dialer == base {
+		t.Fatal("Base dialer must not be returned as-is.")
+	}
+
+	if dialer.ReadBufferSize != 123 {
+		t.Errorf("Setting of the base dialer is not copied: %d.", dialer.ReadBufferSize)
+	}
+
+	if dialer.TLSClientConfig != tlsConfig {
+		t.Error("Specified TLS config is not set.")
+	}
+
+	if dialer.HandshakeTimeout != 5*time.Second {
+		t.Errorf("Specified handshake timeout is not set: %s.", dialer.HandshakeTimeout)
+	}
+
+	if !dialer.EnableCompression {
+		t.Error("Compression is not enabled.")
+	}
+
+	if base.HandshakeTimeout != 3*time.Second || base.TLSClientConfig != nil || base.EnableCompression {
+		t.Errorf("Base dialer is modified: %#v.", base)
+	}
+}
+
 func Test_newConnectionWrapper(t *testing.T) {
-	conn := newConnectionWrapper(&websocket.Conn{})
+	conn := newConnectionWrapper(&websocket.Conn{}, newConnectOption())
 
 	if conn == nil {
 		t.Fatal("connection is not returned.")
@@ -64,6 +181,10 @@ func Test_newConnectionWrapper(t *testing.T) {
 	if wrapper.outgoingEventID == nil {
 		t.Error("id dispenser is not initialized and set.")
 	}
+
+	if wrapper.outgoing == nil {
+		t.Error("outgoing queue is not initialized and set.")
+	}
 }
 
 func TestConnWrapper_Receive(t *testing.T) {
@@ -82,7 +203,7 @@ func TestConnWrapper_Receive(t *testing.T) {
 		slackTimestamp := fmt.Sprintf("%d.000005", timestamp)
 		input := fmt.Sprintf(`{"type": "message", "channel": "%s", "user": "%s", "text": "%s", "ts": "%s"}`, channelID.String(), userID.String(), text, slackTimestamp)
 
-		connWrapper := newConnectionWrapper(conn)
+		connWrapper := newConnectionWrapper(conn, newConnectOption())
 		conn.WriteMessage(websocket.TextMessage, []byte(input))
 		decodedPayload, err := connWrapper.Receive()
 		if err != nil {
@@ -126,7 +247,7 @@ func TestConnWrapper_Send(t *testing.T) {
 		}
 		defer conn.Close()
 
-		connWrapper := newConnectionWrapper(conn)
+		connWrapper := newConnectionWrapper(conn, newConnectOption())
 		message := &OutgoingMessage{}
 		if err := connWrapper.Send(message); err != nil {
 			t.Errorf("error on sending message over WebSocket connection. %#v.", err)
@@ -135,6 +256,261 @@ func TestConnWrapper_Send(t *testing.T) {
 		if message.ID == 0 {
 			t.Errorf("Send() method must append message id.")
 		}
+
+		payloads := []OutgoingPayload{
+			NewTyping("C123"),
+			NewPresenceSubscribe("U061F7AUR"),
+			NewPresenceQuery("U061F7AUR"),
+		}
+		for _, payload := range payloads {
+			if err := connWrapper.Send(payload); err != nil {
+				t.Errorf("error on sending payload over WebSocket connection. %#v.", err)
+			}
+
+			if payload.outgoingEvent().ID <= message.ID {
+				t.Errorf("Send() method must append unique id: %#v.", payload)
+			}
+		}
+	})
+}
+
+func TestConnWrapper_SendWithReply(t *testing.T) {
+	testutil.RunWithWebSocket(func(addr net.Addr) {
+		url := fmt.Sprintf("ws://%s%s", addr, "/reply")
+		conn, err := Connect(context.TODO(), url)
+		if err != nil {
+			t.Fatalf("webSocket connection error: %s.", err.Error())
+		}
+		defer conn.Close()
+
+		// Keep receiving so replies are passed to the corresponding PendingReply
+		go func() {
+			for {
+				_, err := conn.Receive()
+				if err != nil {
+					return
+				}
+			}
+		}()
+
+		t.Run("OK reply", func(t *testing.T) {
+			message := NewOutgoingMessage("C123", "Hello")
+			pending, err := conn.SendWithReply(message)
+			if err != nil {
+				t.Fatalf("Unexpected error is returned: %s.", err.Error())
+			}
+
+			if pending.ID != message.ID {
+				t.Errorf("ID is not shared with the message: %d.", pending.ID)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancel()
+			reply, err := pending.Wait(ctx)
+			if err != nil {
+				t.Fatalf("Unexpected error is returned: %s.", err.Error())
+			}
+
+			if reply.ReplyTo != message.ID {
+				t.Errorf("Reply for another message is given: %d.", reply.ReplyTo)
+			}
+
+			if reply.TimeStamp == nil || reply.TimeStamp.String() == "" {
+				t.Errorf("Timestamp is not given: %#v.", reply)
+			}
+		})
+
+		t.Run("NG reply", func(t *testing.T) {
+			pending, err := conn.SendWithReply(NewOutgoingMessage("C123", ""))
+			if err != nil {
+				t.Fatalf("Unexpected error is returned: %s.", err.Error())
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancel()
+			_, err = pending.Wait(ctx)
+			replyErr, ok := err.(*ReplyError)
+			if !ok {
+				t.Fatalf("Expected error is not returned: %#v.", err)
+			}
+
+			if replyErr.Reply.Error.Code != 2 {
+				t.Errorf("Unexpected error code is given: %d.", replyErr.Reply.Error.Code)
+			}
+		})
+	})
+}
+
+func TestConnWrapper_SendWithReply_Timeout(t *testing.T) {
+	testutil.RunWithWebSocket(func(addr net.Addr) {
+		// Echo server never replies
+		url := fmt.Sprintf("ws://%s%s", addr, "/echo")
+		conn, err := Connect(context.TODO(), url, WithReplyTimeout(10*time.Millisecond))
+		if err != nil {
+			t.Fatalf("webSocket connection error: %s.", err.Error())
+		}
+		defer conn.Close()
+
+		pending, err := conn.SendWithReply(NewOutgoingMessage("C123", "Hello"))
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+		defer cancel()
+		_, err = pending.Wait(ctx)
+		if err != ErrReplyTimeout {
+			t.Errorf("Expected error is not returned: %#v.", err)
+		}
+	})
+}
+
+func TestConnWrapper_Send_Concurrent(t *testing.T) {
+	testutil.RunWithWebSocket(func(addr net.Addr) {
+		url := fmt.Sprintf("ws://%s%s", addr, "/echo")
+		conn, err := Connect(context.TODO(), url, WithOutgoingQueue(5, QueueFullBlock))
+		if err != nil {
+			t.Fatalf("webSocket connection error: %s.", err.Error())
+		}
+		defer conn.Close()
+
+		senders := 20
+		messagesPerSender := 50
+		expected := senders * messagesPerSender
+
+		// Count echoed messages. A frame corrupted by concurrent writes can not be decoded as a message.
+		received := make(chan int, 1)
+		go func() {
+			cnt := 0
+			for cnt < expected {
+				payload, err := conn.Receive()
+				if err != nil {
+					if _, ok := err.(*event.MalformedPayloadError); ok {
+						// Echoed ping
+						continue
+					}
+					break
+				}
+
+				message, ok := payload.(*event.Message)
+				if !ok {
+					t.Errorf("Unexpected payload is given: %#v.", payload)
+					continue
+				}
+				if message.Text != "Hello" {
+					t.Errorf("Unexpected text is given: %s.", message.Text)
+				}
+				cnt++
+			}
+			received <- cnt
+		}()
+
+		mutex := &sync.Mutex{}
+		ids := map[uint]struct{}{}
+		wg := &sync.WaitGroup{}
+		for i := 0; i < senders; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < messagesPerSender; j++ {
+					message := NewOutgoingMessage("C123", "Hello")
+					err := conn.Send(message)
+					if err != nil {
+						t.Errorf("Unexpected error is returned: %s.", err.Error())
+						return
+					}
+
+					mutex.Lock()
+					ids[message.ID] = struct{}{}
+					mutex.Unlock()
+
+					if j%10 == 0 {
+						err = conn.Ping()
+						if err != nil {
+							t.Errorf("Unexpected error is returned on ping: %s.", err.Error())
+							return
+						}
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		select {
+		case cnt := <-received:
+			if cnt != expected {
+				t.Errorf("Expected %d messages, but %d are given.", expected, cnt)
+			}
+
+		case <-time.NewTimer(5 * time.Second).C:
+			t.Fatal("Messages are not echoed.")
+
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		if len(ids) != expected {
+			t.Errorf("Event IDs are not unique: %d.", len(ids))
+		}
+	})
+}
+
+func TestConnWrapper_write(t *testing.T) {
+	t.Run("queue is full", func(t *testing.T) {
+		wrapper := &connWrapper{
+			outgoing:        make(chan *writeRequest, 1),
+			queueFullPolicy: QueueFullError,
+			closed:          make(chan struct{}),
+		}
+		// No writer goroutine is running, so the queue is never consumed
+		wrapper.outgoing <- &writeRequest{}
+
+		err := wrapper.write(&Ping{})
+		if err != ErrQueueFull {
+			t.Errorf("Expected error is not returned: %#v.", err)
+		}
+	})
+
+	t.Run("closed while blocking", func(t *testing.T) {
+		wrapper := &connWrapper{
+			outgoing:        make(chan *writeRequest, 1),
+			queueFullPolicy: QueueFullBlock,
+			closed:          make(chan struct{}),
+		}
+		wrapper.outgoing <- &writeRequest{}
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- wrapper.write(&Ping{})
+		}()
+		close(wrapper.closed)
+
+		select {
+		case err := <-errCh:
+			if err != ErrConnectionClosed {
+				t.Errorf("Expected error is not returned: %#v.", err)
+			}
+
+		case <-time.NewTimer(1 * time.Second).C:
+			t.Fatal("Blocking sender is not released on close.")
+
+		}
+	})
+
+	t.Run("closed connection", func(t *testing.T) {
+		testutil.RunWithWebSocket(func(addr net.Addr) {
+			url := fmt.Sprintf("ws://%s%s", addr, "/echo")
+			conn, err := Connect(context.TODO(), url)
+			if err != nil {
+				t.Fatalf("webSocket connection error: %s.", err.Error())
+			}
+			conn.Close()
+
+			err = conn.Send(NewOutgoingMessage("C123", "Hello"))
+			if err != ErrConnectionClosed {
+				t.Errorf("Expected error is not returned: %#v.", err)
+			}
+		})
 	})
 }
 
@@ -147,13 +523,113 @@ func TestConnWrapper_Ping(t *testing.T) {
 		}
 		defer conn.Close()
 
-		connWrapper := newConnectionWrapper(conn)
+		connWrapper := newConnectionWrapper(conn, newConnectOption())
 		if err := connWrapper.Ping(); err != nil {
 			t.Errorf("error on sending message over WebSocket connection. %#v.", err)
 		}
 	})
 }
 
+func TestConnWrapper_Latency(t *testing.T) {
+	testutil.RunWithWebSocket(func(addr net.Addr) {
+		url := fmt.Sprintf("ws://%s%s", addr, "/pong")
+		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		if err != nil {
+			t.Fatal("can't establish connection with test server")
+		}
+		defer conn.Close()
+
+		measured := make(chan time.Duration, 1)
+		opt := newConnectOption()
+		WithLatencyHandler(func(d time.Duration) { measured <- d })(opt)
+		connWrapper := newConnectionWrapper(conn, opt)
+
+		if err := connWrapper.Ping(); err != nil {
+			t.Fatalf("error on sending ping: %s.", err.Error())
+		}
+
+		payload, err := connWrapper.Receive()
+		if err != nil {
+			t.Fatalf("error on payload reception: %s.", err.Error())
+		}
+		if _, ok := payload.(*Pong); !ok {
+			t.Fatalf("received payload is not Pong: %#v.", payload)
+		}
+
+		select {
+		case d := <-measured:
+			if d <= 0 {
+				t.Errorf("Invalid latency is given: %s.", d)
+			}
+
+		default:
+			t.Error("Latency handler is not called.")
+		}
+
+		latency := connWrapper.(LatencyReporter).Latency()
+		if latency <= 0 {
+			t.Errorf("Latency is not stored: %s.", latency)
+		}
+	})
+}
+
+func TestConnWrapper_keepAlive(t *testing.T) {
+	t.Run("pong is returned", func(t *testing.T) {
+		testutil.RunWithWebSocket(func(addr net.Addr) {
+			url := fmt.Sprintf("ws://%s%s", addr, "/pong")
+			conn, err := Connect(context.TODO(), url, WithKeepAlive(10*time.Millisecond, 2))
+			if err != nil {
+				t.Fatalf("webSocket connection error: %s.", err.Error())
+			}
+			defer conn.Close()
+
+			// Pongs keep coming as long as the connection is alive
+			for i := 0; i < 5; i++ {
+				payload, err := conn.Receive()
+				if err != nil {
+					t.Fatalf("Unexpected error is returned: %s.", err.Error())
+				}
+				if _, ok := payload.(*Pong); !ok {
+					t.Fatalf("received payload is not Pong: %#v.", payload)
+				}
+			}
+		})
+	})
+
+	t.Run("pong is not returned", func(t *testing.T) {
+		testutil.RunWithWebSocket(func(addr net.Addr) {
+			// Echo server returns ping as-is, which is not a pong
+			url := fmt.Sprintf("ws://%s%s", addr, "/echo")
+			conn, err := Connect(context.TODO(), url, WithKeepAlive(10*time.Millisecond, 2))
+			if err != nil {
+				t.Fatalf("webSocket connection error: %s.", err.Error())
+			}
+			defer conn.Close()
+
+			timeout := time.After(1 * time.Second)
+			for {
+				select {
+				case <-timeout:
+					t.Fatal("Dead connection is not detected.")
+
+				default:
+					// Continue
+
+				}
+
+				_, err := conn.Receive()
+				if err == ErrPongTimeout {
+					return
+				}
+
+				if _, ok := err.(*event.MalformedPayloadError); !ok {
+					t.Fatalf("Unexpected error is returned: %#v.", err)
+				}
+			}
+		})
+	})
+}
+
 func TestConnWrapper_Close(t *testing.T) {
 	testutil.RunWithWebSocket(func(addr net.Addr) {
 		url := fmt.Sprintf("ws://%s%s", addr, "/echo")
@@ -162,7 +638,7 @@ func TestConnWrapper_Close(t *testing.T) {
 			t.Fatal("can't establish connection with test server")
 		}
 
-		connWrapper := newConnectionWrapper(conn)
+		connWrapper := newConnectionWrapper(conn, newConnectOption())
 
 		if err := connWrapper.Close(); err != nil {
 			t.Fatal("error on connection close")
@@ -1,6 +1,7 @@
 package rtmapi
 
 import (
+	"encoding/json"
 	"github.com/oklahomer/golack/v2/event"
 )
 
@@ -17,6 +18,16 @@ type OutgoingEvent struct {
 	ID uint `json:"id"`
 }
 
+// OutgoingPayload is a payload that can be sent via PayloadSender.
+// Any struct that embeds OutgoingEvent satisfies this interface, and its ID is assigned on sending.
+type OutgoingPayload interface {
+	outgoingEvent() *OutgoingEvent
+}
+
+func (e *OutgoingEvent) outgoingEvent() *OutgoingEvent {
+	return e
+}
+
 // OutgoingMessage represents a simple message sent from client to Slack server via WebSocket connection.
 // This is the only format RTM API supports. To send more richly formatted message, use Web API.
 // https://api.slack.com/rtm#sending_messages
@@ -63,3 +74,87 @@ func NewPing(eventID *OutgoingEventID) *Ping {
 		},
 	}
 }
+
+// Typing is an event that can be sent to tell others that the user is typing in the channel.
+// https://api.slack.com/rtm#typing_indicators
+type Typing struct {
+	OutgoingEvent
+	ChannelID event.ChannelID `json:"channel"`
+}
+
+// NewTyping creates new Typing instance with given channel.
+func NewTyping(channel event.ChannelID) *Typing {
+	return &Typing{
+		ChannelID: channel,
+		OutgoingEvent: OutgoingEvent{
+			TypedEvent: event.TypedEvent{Type: "typing"},
+		},
+	}
+}
+
+// PresenceSubscribe is an event that can be sent to subscribe to presence_change events of the given users.
+// The subscription is replaced with the new list every time this is sent.
+// https://api.slack.com/docs/presence-and-status#subscriptions
+type PresenceSubscribe struct {
+	OutgoingEvent
+	*event.PresenceSubscribe
+}
+
+// NewPresenceSubscribe creates new PresenceSubscribe instance with given users.
+func NewPresenceSubscribe(userIDs ...event.UserID) *PresenceSubscribe {
+	typed := event.TypedEvent{Type: "presence_sub"}
+	return &PresenceSubscribe{
+		OutgoingEvent: OutgoingEvent{
+			TypedEvent: typed,
+		},
+		PresenceSubscribe: &event.PresenceSubscribe{
+			TypedEvent: typed,
+			UserIDs:    userIDs,
+		},
+	}
+}
+
+// MarshalJSON encodes event.PresenceSubscribe along with the ID assigned on sending.
+// Both OutgoingEvent and event.PresenceSubscribe declare "type" field, so encoding/json would otherwise drop it as ambiguous.
+func (p PresenceSubscribe) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		ID uint `json:"id"`
+		*event.PresenceSubscribe
+	}{
+		ID:                p.ID,
+		PresenceSubscribe: p.PresenceSubscribe,
+	})
+}
+
+// PresenceQuery is an event that can be sent to request presence_change events of the given users.
+// https://api.slack.com/docs/presence-and-status#batching
+type PresenceQuery struct {
+	OutgoingEvent
+	*event.PresenceQuery
+}
+
+// NewPresenceQuery creates new PresenceQuery instance with given users.
+func NewPresenceQuery(userIDs ...event.UserID) *PresenceQuery {
+	typed := event.TypedEvent{Type: "presence_query"}
+	return &PresenceQuery{
+		OutgoingEvent: OutgoingEvent{
+			TypedEvent: typed,
+		},
+		PresenceQuery: &event.PresenceQuery{
+			TypedEvent: typed,
+			UserIDs:    userIDs,
+		},
+	}
+}
+
+// MarshalJSON encodes event.PresenceQuery along with the ID assigned on sending.
+// See PresenceSubscribe.MarshalJSON for the reason.
+func (q PresenceQuery) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		ID uint `json:"id"`
+		*event.PresenceQuery
+	}{
+		ID:            q.ID,
+		PresenceQuery: q.PresenceQuery,
+	})
+}
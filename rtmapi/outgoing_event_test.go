@@ -66,3 +66,63 @@ func TestOutgoingMessage_WithThreadTimeStamp(t *testing.T) {
 		t.Errorf("Passed timestamp is not set: %s.", message.ThreadTimeStamp)
 	}
 }
+
+func TestNewTyping(t *testing.T) {
+	channelID := event.ChannelID("channel")
+
+	typing := NewTyping(channelID)
+
+	if typing.Type != "typing" {
+		t.Errorf("Unexpected type is set: %s.", typing.Type)
+	}
+
+	if typing.ChannelID != channelID {
+		t.Errorf("Passed channelID is not set: %s.", typing.ChannelID)
+	}
+}
+
+func TestNewPresenceSubscribe(t *testing.T) {
+	subscribe := NewPresenceSubscribe("U061F7AUR", "W123456")
+	subscribe.ID = 3
+
+	if subscribe.OutgoingEvent.Type != "presence_sub" {
+		t.Errorf("Unexpected type is set: %s.", subscribe.OutgoingEvent.Type)
+	}
+
+	if len(subscribe.UserIDs) != 2 {
+		t.Errorf("Passed user IDs are not set: %#v.", subscribe.UserIDs)
+	}
+
+	val, err := json.Marshal(subscribe)
+	if err != nil {
+		t.Fatalf("error occured while encoding. %s.", err.Error())
+	}
+
+	expected := `{"id":3,"type":"presence_sub","ids":["U061F7AUR","W123456"]}`
+	if string(val) != expected {
+		t.Errorf("Unexpected JSON is returned: %s.", string(val))
+	}
+}
+
+func TestNewPresenceQuery(t *testing.T) {
+	query := NewPresenceQuery("U061F7AUR")
+	query.ID = 5
+
+	if query.OutgoingEvent.Type != "presence_query" {
+		t.Errorf("Unexpected type is set: %s.", query.OutgoingEvent.Type)
+	}
+
+	if len(query.UserIDs) != 1 || query.UserIDs[0] != "U061F7AUR" {
+		t.Errorf("Passed user IDs are not set: %#v.", query.UserIDs)
+	}
+
+	val, err := json.Marshal(query)
+	if err != nil {
+		t.Fatalf("error occured while encoding. %s.", err.Error())
+	}
+
+	expected := `{"id":5,"type":"presence_query","ids":["U061F7AUR"]}`
+	if string(val) != expected {
+		t.Errorf("Unexpected JSON is returned: %s.", string(val))
+	}
+}
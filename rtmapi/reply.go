@@ -1,6 +1,13 @@
 package rtmapi
 
-import "github.com/oklahomer/golack/v2/event"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/oklahomer/golack/v2/event"
+	"sync"
+	"time"
+)
 
 // Reply represents a response from Slack.
 // No matter if the response represents successful operation or not,
@@ -31,3 +38,128 @@ type ReplyErrorReason struct {
 	Code    int    `json:"code"`
 	Message string `json:"msg"`
 }
+
+var (
+	// ErrReplyTimeout is returned when the reply for a sent message is not given within the timeout.
+	ErrReplyTimeout = errors.New("reply is not given within the timeout")
+
+	// ErrConnectionClosed is returned when the connection is closed before the reply for a sent message is given.
+	ErrConnectionClosed = errors.New("connection is closed")
+)
+
+// ReplyError is returned when Slack replies with NGReply to a sent message.
+type ReplyError struct {
+	Reply *NGReply
+}
+
+// Error returns its error string.
+func (e *ReplyError) Error() string {
+	return fmt.Sprintf("failed to send message with id %d. code: %d. message: %s", e.Reply.ReplyTo, e.Reply.Error.Code, e.Reply.Error.Message)
+}
+
+// PendingReply represents a reply that is expected to be given for a sent message.
+// Call Wait to receive the OKReply that contains the timestamp of the posted message.
+// The reply is received as long as the connection's Receive method is continuously called.
+type PendingReply struct {
+	// ID is the identifier of the sent message.
+	ID    uint
+	done  chan struct{}
+	reply *OKReply
+	err   error
+}
+
+// Done returns a channel that is closed when the reply is given, the timeout is reached, or the connection is closed.
+func (p *PendingReply) Done() <-chan struct{} {
+	return p.done
+}
+
+// Wait blocks until the reply is given or ctx is canceled.
+// When Slack replies with NGReply, *ReplyError is returned.
+// When the reply is not given within the timeout, ErrReplyTimeout is returned.
+func (p *PendingReply) Wait(ctx context.Context) (*OKReply, error) {
+	select {
+	case <-p.done:
+		return p.reply, p.err
+
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	}
+}
+
+// replyRegistry keeps track of the sent messages and resolves the corresponding PendingReply on reply reception.
+type replyRegistry struct {
+	mutex   *sync.Mutex
+	pending map[uint]*pendingEntry
+}
+
+type pendingEntry struct {
+	reply *PendingReply
+	timer *time.Timer
+}
+
+func newReplyRegistry() *replyRegistry {
+	return &replyRegistry{
+		mutex:   &sync.Mutex{},
+		pending: map[uint]*pendingEntry{},
+	}
+}
+
+func (r *replyRegistry) register(id uint, timeout time.Duration) *PendingReply {
+	reply := &PendingReply{
+		ID:   id,
+		done: make(chan struct{}),
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.pending[id] = &pendingEntry{
+		reply: reply,
+		timer: time.AfterFunc(timeout, func() {
+			r.resolve(id, nil, ErrReplyTimeout)
+		}),
+	}
+
+	return reply
+}
+
+func (r *replyRegistry) resolve(id uint, reply *OKReply, err error) {
+	r.mutex.Lock()
+	entry, ok := r.pending[id]
+	delete(r.pending, id)
+	r.mutex.Unlock()
+
+	if !ok {
+		// Already resolved or not sent with reply expectation
+		return
+	}
+
+	entry.timer.Stop()
+	entry.reply.reply = reply
+	entry.reply.err = err
+	close(entry.reply.done)
+}
+
+func (r *replyRegistry) receive(payload DecodedPayload) {
+	switch typed := payload.(type) {
+	case *OKReply:
+		r.resolve(typed.ReplyTo, typed, nil)
+
+	case *NGReply:
+		r.resolve(typed.ReplyTo, nil, &ReplyError{Reply: typed})
+
+	}
+}
+
+func (r *replyRegistry) closeAll(err error) {
+	r.mutex.Lock()
+	var ids []uint
+	for id := range r.pending {
+		ids = append(ids, id)
+	}
+	r.mutex.Unlock()
+
+	for _, id := range ids {
+		r.resolve(id, nil, err)
+	}
+}
@@ -0,0 +1,89 @@
+package rtmapi
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReplyError_Error(t *testing.T) {
+	err := &ReplyError{
+		Reply: &NGReply{
+			Reply: Reply{
+				OK:      false,
+				ReplyTo: 1,
+			},
+			Error: ReplyErrorReason{
+				Code:    2,
+				Message: "message text is missing",
+			},
+		},
+	}
+
+	if !strings.Contains(err.Error(), "message text is missing") {
+		t.Errorf("Error message is not included: %s.", err.Error())
+	}
+}
+
+func TestPendingReply_Wait(t *testing.T) {
+	t.Run("context cancellation", func(t *testing.T) {
+		pending := &PendingReply{ID: 1, done: make(chan struct{})}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := pending.Wait(ctx)
+		if err != context.Canceled {
+			t.Errorf("Expected error is not returned: %#v.", err)
+		}
+	})
+}
+
+func Test_replyRegistry(t *testing.T) {
+	t.Run("resolve with OKReply", func(t *testing.T) {
+		registry := newReplyRegistry()
+		pending := registry.register(1, 1*time.Second)
+
+		expected := &OKReply{Reply: Reply{OK: true, ReplyTo: 1}}
+		registry.receive(expected)
+
+		reply, err := pending.Wait(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+		if reply != expected {
+			t.Errorf("Expected reply is not given: %#v.", reply)
+		}
+		if len(registry.pending) != 0 {
+			t.Errorf("Resolved entry is not removed: %#v.", registry.pending)
+		}
+	})
+
+	t.Run("ignore unknown reply", func(t *testing.T) {
+		registry := newReplyRegistry()
+		pending := registry.register(1, 1*time.Second)
+
+		registry.receive(&OKReply{Reply: Reply{OK: true, ReplyTo: 2}})
+
+		select {
+		case <-pending.Done():
+			t.Error("PendingReply is resolved with a reply for another message.")
+
+		default:
+			// O.K.
+
+		}
+	})
+
+	t.Run("close all", func(t *testing.T) {
+		registry := newReplyRegistry()
+		pending := registry.register(1, 1*time.Second)
+
+		registry.closeAll(ErrConnectionClosed)
+
+		_, err := pending.Wait(context.Background())
+		if err != ErrConnectionClosed {
+			t.Errorf("Expected error is not returned: %#v.", err)
+		}
+	})
+}
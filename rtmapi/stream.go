@@ -0,0 +1,158 @@
+package rtmapi
+
+import (
+	"context"
+	"github.com/oklahomer/golack/v2/event"
+	"sync"
+)
+
+// EventStream passes payloads received over a connection through channels.
+// Use Stream to start receiving.
+type EventStream struct {
+	events        chan DecodedPayload
+	payloadErrors chan error
+	done          chan struct{}
+	err           error
+	mutex         *sync.RWMutex
+}
+
+// payloadErrorBufferSize is the number of errors that PayloadErrors channel holds before dropping subsequent ones.
+const payloadErrorBufferSize = 10
+
+// Stream starts receiving payloads over the given connection and returns EventStream to subscribe to them.
+//
+// Empty payloads are silently skipped.
+// Errors that are specific to a single payload such as *event.MalformedPayloadError and *event.UnknownPayloadTypeError are passed through PayloadErrors
+// while the stream keeps receiving subsequent payloads.
+// Any other error is considered fatal: the stream stops, the connection is closed, and the error becomes available via Err.
+// When ctx is canceled, the connection is closed and the stream stops cleanly.
+//
+// Events channel must be consumed since the stream blocks until each received payload is read.
+// PayloadErrors channel is buffered and its consumption is optional: when its buffer is full, subsequent errors are dropped.
+func Stream(ctx context.Context, conn Connection) *EventStream {
+	s := &EventStream{
+		events:        make(chan DecodedPayload),
+		payloadErrors: make(chan error, payloadErrorBufferSize),
+		done:          make(chan struct{}),
+		mutex:         &sync.RWMutex{},
+	}
+
+	go s.run(ctx, conn)
+
+	return s
+}
+
+// Events returns a channel that passes received payloads.
+// The channel is closed when the stream stops.
+func (s *EventStream) Events() <-chan DecodedPayload {
+	return s.events
+}
+
+// PayloadErrors returns a channel that passes errors on decoding individual payloads.
+// Errors are dropped when the buffer of this channel is full.
+// The channel is closed when the stream stops.
+func (s *EventStream) PayloadErrors() <-chan error {
+	return s.payloadErrors
+}
+
+// Done returns a channel that is closed when the stream stops.
+func (s *EventStream) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err returns the reason why the stream stopped.
+// This returns ctx.Err() when the given context is canceled, or the fatal connection error otherwise.
+// Nil is returned while the stream is running.
+func (s *EventStream) Err() error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.err
+}
+
+func (s *EventStream) run(ctx context.Context, conn Connection) {
+	err := receive(ctx, conn, func(payload DecodedPayload) error {
+		select {
+		case s.events <- payload:
+			return nil
+
+		case <-ctx.Done():
+			return ctx.Err()
+
+		}
+	}, func(payloadErr error) error {
+		select {
+		case s.payloadErrors <- payloadErr:
+			// O.K.
+
+		default:
+			// Nobody reads errors or the buffer is full. Drop it so Events keep flowing.
+
+		}
+		return nil
+	})
+
+	//noinspection ALL
+	conn.Close()
+
+	if ctx.Err() != nil {
+		// The error returned by Receive is caused by closing the connection on cancellation
+		err = ctx.Err()
+	}
+
+	s.mutex.Lock()
+	s.err = err
+	s.mutex.Unlock()
+
+	close(s.events)
+	close(s.payloadErrors)
+	close(s.done)
+}
+
+// receive keeps receiving payloads over the given connection until a fatal error occurs.
+// Empty payloads are silently skipped.
+// Each decoded payload is passed to onPayload, and each error specific to a single payload such as *event.MalformedPayloadError is passed to onPayloadError.
+// When either of them returns an error, the loop stops and returns that error.
+// When ctx is canceled, the connection is closed so the blocking Receive call returns.
+func receive(ctx context.Context, conn Connection, onPayload func(DecodedPayload) error, onPayloadError func(error) error) error {
+	// Close the connection on cancellation so the blocking Receive call returns
+	finished := make(chan struct{})
+	defer close(finished)
+	go func() {
+		select {
+		case <-ctx.Done():
+			//noinspection ALL
+			conn.Close()
+
+		case <-finished:
+			// Receive loop is already finished
+
+		}
+	}()
+
+	for {
+		payload, err := conn.Receive()
+		if err != nil {
+			if err == event.ErrEmptyPayload {
+				continue
+			}
+
+			switch err.(type) {
+			case *event.MalformedPayloadError, *event.UnknownPayloadTypeError:
+				err = onPayloadError(err)
+				if err != nil {
+					return err
+				}
+				continue
+
+			default:
+				return err
+
+			}
+		}
+
+		err = onPayload(payload)
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package rtmapi
+
+import (
+	"context"
+	"errors"
+	"github.com/oklahomer/golack/v2/event"
+	"testing"
+	"time"
+)
+
+func TestStream(t *testing.T) {
+	t.Run("fatal error", func(t *testing.T) {
+		fatalErr := errors.New("DUMMY")
+		type received struct {
+			payload DecodedPayload
+			err     error
+		}
+		queue := []received{
+			{payload: &event.Hello{}},
+			{err: event.ErrEmptyPayload},
+			{err: event.NewMalformedPayloadError("malformed")},
+			{payload: &Pong{}},
+			{err: fatalErr},
+		}
+		closed := false
+		conn := &DummyConnection{
+			ReceiveFunc: func() (DecodedPayload, error) {
+				r := queue[0]
+				queue = queue[1:]
+				return r.payload, r.err
+			},
+			CloseFunc: func() error {
+				closed = true
+				return nil
+			},
+		}
+
+		stream := Stream(context.TODO(), conn)
+
+		var payloads []DecodedPayload
+		var payloadErrs []error
+		events := stream.Events()
+		errs := stream.PayloadErrors()
+		for events != nil || errs != nil {
+			select {
+			case payload, ok := <-events:
+				if !ok {
+					events = nil
+					continue
+				}
+				payloads = append(payloads, payload)
+
+			case err, ok := <-errs:
+				if !ok {
+					errs = nil
+					continue
+				}
+				payloadErrs = append(payloadErrs, err)
+
+			case <-time.NewTimer(1 * time.Second).C:
+				t.Fatal("Stream is not stopped.")
+
+			}
+		}
+
+		<-stream.Done()
+
+		if len(payloads) != 2 {
+			t.Errorf("Unexpected payloads are given: %#v.", payloads)
+		}
+
+		if len(payloadErrs) != 1 {
+			t.Fatalf("Unexpected errors are given: %#v.", payloadErrs)
+		}
+		if _, ok := payloadErrs[0].(*event.MalformedPayloadError); !ok {
+			t.Errorf("Unexpected error is given: %#v.", payloadErrs[0])
+		}
+
+		if stream.Err() != fatalErr {
+			t.Errorf("Expected error is not returned: %#v.", stream.Err())
+		}
+
+		if !closed {
+			t.Error("Connection is not closed.")
+		}
+	})
+
+	t.Run("events only", func(t *testing.T) {
+		count := payloadErrorBufferSize + 5
+		conn := &DummyConnection{
+			ReceiveFunc: func() (DecodedPayload, error) {
+				if count > 0 {
+					count--
+					return nil, event.NewMalformedPayloadError("malformed")
+				}
+				return &event.Hello{}, nil
+			},
+			CloseFunc: func() error {
+				return nil
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		stream := Stream(ctx, conn)
+
+		// Errors are not read at all
+		select {
+		case payload := <-stream.Events():
+			if _, ok := payload.(*event.Hello); !ok {
+				t.Errorf("Unexpected payload is given: %#v.", payload)
+			}
+
+		case <-time.NewTimer(1 * time.Second).C:
+			t.Fatal("Stream is blocked by unread errors.")
+
+		}
+
+		if len(stream.PayloadErrors()) != payloadErrorBufferSize {
+			t.Errorf("Unexpected number of errors are buffered: %d.", len(stream.PayloadErrors()))
+		}
+	})
+
+	t.Run("context cancellation", func(t *testing.T) {
+		closed := make(chan struct{})
+		conn := &DummyConnection{
+			ReceiveFunc: func() (DecodedPayload, error) {
+				<-closed
+				return nil, ErrConnectionClosed
+			},
+			CloseFunc: func() error {
+				select {
+				case <-closed:
+					// Already closed
+
+				default:
+					close(closed)
+
+				}
+				return nil
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		stream := Stream(ctx, conn)
+
+		if stream.Err() != nil {
+			t.Errorf("Error is returned while running: %#v.", stream.Err())
+		}
+
+		cancel()
+
+		select {
+		case <-stream.Done():
+			// O.K.
+
+		case <-time.NewTimer(1 * time.Second).C:
+			t.Fatal("Stream is not stopped.")
+
+		}
+
+		if stream.Err() != context.Canceled {
+			t.Errorf("Expected error is not returned: %#v.", stream.Err())
+		}
+
+		if _, ok := <-stream.Events(); ok {
+			t.Error("Events channel is not closed.")
+		}
+	})
+}
@@ -0,0 +1,298 @@
+package rtmapi
+
+import (
+	"context"
+	"errors"
+	"github.com/oklahomer/golack/v2/event"
+	"log"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrNotConnected is returned when a payload is sent while the Supervisor has no established connection.
+	ErrNotConnected = errors.New("connection is not established")
+
+	// ErrGoodBye is passed to the disconnect hook when the connection is closed due to the goodbye event.
+	ErrGoodBye = errors.New("goodbye event is given")
+)
+
+// URLProvider returns a WebSocket URL to connect to.
+// Typically, this calls rtm.connect method and returns the URL in its response.
+type URLProvider func(ctx context.Context) (string, error)
+
+// Connector establishes a new connection with the given url.
+// By default, Supervisor uses Connect with the options given by WithConnectOptions.
+type Connector func(ctx context.Context, url string) (Connection, error)
+
+// SupervisorOption changes the behavior of Supervisor.
+// Pass this to NewSupervisor.
+type SupervisorOption func(*Supervisor)
+
+// WithBackoff sets the Backoff to calculate the interval between reconnection attempts.
+func WithBackoff(backoff *Backoff) SupervisorOption {
+	return func(s *Supervisor) {
+		s.backoff = backoff
+	}
+}
+
+// WithConnector sets the function to establish a connection.
+// Connect is used by default.
+func WithConnector(connector Connector) SupervisorOption {
+	return func(s *Supervisor) {
+		s.connector = connector
+	}
+}
+
+// WithConnectOptions sets the options that are passed to Connect on every connection attempt.
+// This is ignored when a custom Connector is given by WithConnector.
+func WithConnectOptions(options ...ConnectOption) SupervisorOption {
+	return func(s *Supervisor) {
+		s.connectOptions = options
+	}
+}
+
+// WithOnConnect sets a function that is called every time a new connection is established.
+func WithOnConnect(fnc func(Connection)) SupervisorOption {
+	return func(s *Supervisor) {
+		s.onConnect = fnc
+	}
+}
+
+// WithOnDisconnect sets a function that is called every time the connection is closed.
+// The given error represents the cause of disconnection.
+func WithOnDisconnect(fnc func(error)) SupervisorOption {
+	return func(s *Supervisor) {
+		s.onDisconnect = fnc
+	}
+}
+
+// WithEventBufferSize sets the buffer size of the channel returned by Supervisor.Events.
+func WithEventBufferSize(size int) SupervisorOption {
+	return func(s *Supervisor) {
+		s.bufferSize = size
+	}
+}
+
+// Supervisor owns the lifecycle of RTM connection.
+// This establishes a connection, reconnects with exponential backoff on failure, and reconnects proactively on goodbye event.
+// Received payloads are passed through a single channel that survives reconnections.
+type Supervisor struct {
+	urlProvider    URLProvider
+	connector      Connector
+	connectOptions []ConnectOption
+	backoff        *Backoff
+	onConnect      func(Connection)
+	onDisconnect   func(error)
+	bufferSize     int
+	events         chan DecodedPayload
+	conn           Connection
+	mutex          *sync.RWMutex
+}
+
+var _ PayloadSender = (*Supervisor)(nil)
+
+// NewSupervisor creates a new Supervisor with the given urlProvider and options.
+// Call Run to start the supervision.
+func NewSupervisor(urlProvider URLProvider, options ...SupervisorOption) *Supervisor {
+	s := &Supervisor{
+		urlProvider: urlProvider,
+		backoff:     NewBackoff(),
+		bufferSize:  100,
+		mutex:       &sync.RWMutex{},
+	}
+
+	for _, opt := range options {
+		opt(s)
+	}
+
+	if s.connector == nil {
+		s.connector = func(ctx context.Context, url string) (Connection, error) {
+			return Connect(ctx, url, s.connectOptions...)
+		}
+	}
+
+	s.events = make(chan DecodedPayload, s.bufferSize)
+	return s
+}
+
+// Events returns a channel that passes received payloads.
+// The channel is closed when Run returns.
+func (s *Supervisor) Events() <-chan DecodedPayload {
+	return s.events
+}
+
+// Send sends the given payload over the current connection.
+// ErrNotConnected is returned when no connection is established at the moment.
+func (s *Supervisor) Send(payload OutgoingPayload) error {
+	conn := s.currentConnection()
+	if conn == nil {
+		return ErrNotConnected
+	}
+	return conn.Send(payload)
+}
+
+// SendWithReply sends the given message over the current connection and returns PendingReply to receive the corresponding reply.
+// ErrNotConnected is returned when no connection is established at the moment.
+// When the connection is closed before the reply is given, PendingReply resolves to ErrConnectionClosed.
+func (s *Supervisor) SendWithReply(message *OutgoingMessage) (*PendingReply, error) {
+	conn := s.currentConnection()
+	if conn == nil {
+		return nil, ErrNotConnected
+	}
+	return conn.SendWithReply(message)
+}
+
+// Ping sends a ping over the current connection.
+// ErrNotConnected is returned when no connection is established at the moment.
+func (s *Supervisor) Ping() error {
+	conn := s.currentConnection()
+	if conn == nil {
+		return ErrNotConnected
+	}
+	return conn.Ping()
+}
+
+// Run establishes a connection and keeps it alive until ctx is canceled.
+// This blocks and returns ctx.Err() on cancellation.
+// This must be called only once.
+func (s *Supervisor) Run(ctx context.Context) error {
+	defer close(s.events)
+
+	attempt := 0
+	reconnectURL := ""
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		conn, err := s.connect(ctx, reconnectURL)
+		reconnectURL = ""
+		if err != nil {
+			log.Printf("Failed to establish RTM connection: %s", err.Error())
+			if !s.wait(ctx, attempt) {
+				return ctx.Err()
+			}
+			attempt++
+			continue
+		}
+
+		s.setConnection(conn)
+		if s.onConnect != nil {
+			s.onConnect(conn)
+		}
+
+		var hello bool
+		reconnectURL, hello, err = s.receive(ctx, conn)
+
+		s.setConnection(nil)
+		//noinspection ALL
+		conn.Close()
+		if s.onDisconnect != nil {
+			s.onDisconnect(err)
+		}
+
+		if hello {
+			// The connection was once established successfully
+			attempt = 0
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if err == ErrGoodBye {
+			// Slack is about to close the connection. Reconnect after the initial interval so a server that repeatedly says goodbye
+			// does not make this call rtm.connect in a tight loop.
+			if !s.wait(ctx, 0) {
+				return ctx.Err()
+			}
+			continue
+		}
+
+		if !s.wait(ctx, attempt) {
+			return ctx.Err()
+		}
+		attempt++
+	}
+}
+
+func (s *Supervisor) connect(ctx context.Context, reconnectURL string) (Connection, error) {
+	if reconnectURL != "" {
+		conn, err := s.connector(ctx, reconnectURL)
+		if err == nil {
+			return conn, nil
+		}
+		// Fall back to the URL provider
+	}
+
+	url, err := s.urlProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.connector(ctx, url)
+}
+
+// receive passes incoming payloads to the events channel until the connection fails.
+// This returns the latest reconnect URL, whether hello event is given, and the cause of disconnection.
+func (s *Supervisor) receive(ctx context.Context, conn Connection) (string, bool, error) {
+	reconnectURL := ""
+	hello := false
+	err := receive(ctx, conn, func(payload DecodedPayload) error {
+		switch typed := payload.(type) {
+		case *event.Hello:
+			hello = true
+
+		case *event.ReconnectURL:
+			reconnectURL = typed.URL
+
+		}
+
+		select {
+		case s.events <- payload:
+			// O.K.
+
+		case <-ctx.Done():
+			return ctx.Err()
+
+		}
+
+		if _, ok := payload.(*event.GoodBye); ok {
+			return ErrGoodBye
+		}
+
+		return nil
+	}, func(payloadErr error) error {
+		log.Printf("Failed to decode payload: %s", payloadErr.Error())
+		return nil
+	})
+
+	return reconnectURL, hello, err
+}
+
+func (s *Supervisor) wait(ctx context.Context, attempt int) bool {
+	timer := time.NewTimer(s.backoff.Duration(attempt))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+
+	case <-ctx.Done():
+		return false
+
+	}
+}
+
+func (s *Supervisor) currentConnection() Connection {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.conn
+}
+
+func (s *Supervisor) setConnection(conn Connection) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.conn = conn
+}
@@ -0,0 +1,404 @@
+package rtmapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/oklahomer/golack/v2/event"
+	"github.com/oklahomer/golack/v2/testutil"
+	"net"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBackoff_Duration(t *testing.T) {
+	backoff := &Backoff{
+		Initial:    1 * time.Second,
+		Max:        10 * time.Second,
+		Multiplier: 2,
+		Jitter:     0,
+	}
+
+	tests := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{attempt: 0, expected: 1 * time.Second},
+		{attempt: 1, expected: 2 * time.Second},
+		{attempt: 3, expected: 8 * time.Second},
+		{attempt: 4, expected: 10 * time.Second},
+		{attempt: 1000, expected: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		d := backoff.Duration(tt.attempt)
+		if d != tt.expected {
+			t.Errorf("Expected %s on attempt %d, but was %s.", tt.expected, tt.attempt, d)
+		}
+	}
+}
+
+func TestBackoff_Duration_Jitter(t *testing.T) {
+	backoff := &Backoff{
+		Initial:    10 * time.Second,
+		Max:        10 * time.Second,
+		Multiplier: 2,
+		Jitter:     0.2,
+	}
+
+	for i := 0; i < 100; i++ {
+		d := backoff.Duration(0)
+		if d < 8*time.Second || d > 12*time.Second {
+			t.Fatalf("Duration is out of expected range: %s.", d)
+		}
+	}
+}
+
+func TestNewSupervisor(t *testing.T) {
+	onConnect := func(_ Connection) {}
+	backoff := &Backoff{}
+	supervisor := NewSupervisor(
+		func(_ context.Context) (string, error) { return "", nil },
+		WithBackoff(backoff),
+		WithOnConnect(onConnect),
+		WithEventBufferSize(3),
+	)
+
+	if supervisor.backoff != backoff {
+		t.Errorf("Specified backoff is not set.")
+	}
+
+	if supervisor.onConnect == nil {
+		t.Error("Specified hook is not set.")
+	}
+
+	if cap(supervisor.events) != 3 {
+		t.Errorf("Specified buffer size is not applied: %d.", cap(supervisor.events))
+	}
+
+	if supervisor.connector == nil {
+		t.Error("Default connector is not set.")
+	}
+}
+
+func TestSupervisor_Send(t *testing.T) {
+	supervisor := NewSupervisor(func(_ context.Context) (string, error) { return "", nil })
+
+	err := supervisor.Send(NewOutgoingMessage("C123", "Hello"))
+	if err != ErrNotConnected {
+		t.Errorf("Expected error is not returned: %#v.", err)
+	}
+
+	_, err = supervisor.SendWithReply(NewOutgoingMessage("C123", "Hello"))
+	if err != ErrNotConnected {
+		t.Errorf("Expected error is not returned: %#v.", err)
+	}
+
+	err = supervisor.Ping()
+	if err != ErrNotConnected {
+		t.Errorf("Expected error is not returned: %#v.", err)
+	}
+}
+
+func TestSupervisor_Run(t *testing.T) {
+	t.Run("reconnect on goodbye", func(t *testing.T) {
+		testutil.RunWithWebSocket(func(addr net.Addr) {
+			mutex := &sync.Mutex{}
+			connected := 0
+			var disconnectErrs []error
+			supervisor := NewSupervisor(
+				func(_ context.Context) (string, error) {
+					return fmt.Sprintf("ws://%s%s", addr, "/goodbye"), nil
+				},
+				WithBackoff(&Backoff{Initial: 100 * time.Millisecond, Max: 100 * time.Millisecond, Multiplier: 1}),
+				WithOnConnect(func(_ Connection) {
+					mutex.Lock()
+					defer mutex.Unlock()
+					connected++
+				}),
+				WithOnDisconnect(func(err error) {
+					mutex.Lock()
+					defer mutex.Unlock()
+					disconnectErrs = append(disconnectErrs, err)
+				}),
+			)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			errCh := make(chan error, 1)
+			go func() {
+				errCh <- supervisor.Run(ctx)
+			}()
+
+			// hello, goodbye, and then hello on the new connection
+			var received []DecodedPayload
+			var receivedAt []time.Time
+			timeout := time.NewTimer(3 * time.Second)
+			for len(received) < 3 {
+				select {
+				case payload := <-supervisor.Events():
+					received = append(received, payload)
+					receivedAt = append(receivedAt, time.Now())
+
+				case <-timeout.C:
+					t.Fatalf("Expected payloads are not given: %#v.", received)
+
+				}
+			}
+			cancel()
+
+			if _, ok := received[0].(*event.Hello); !ok {
+				t.Errorf("Expected hello is not given: %#v.", received[0])
+			}
+			if _, ok := received[1].(*event.GoodBye); !ok {
+				t.Errorf("Expected goodbye is not given: %#v.", received[1])
+			}
+			if _, ok := received[2].(*event.Hello); !ok {
+				t.Errorf("Expected hello is not given on reconnection: %#v.", received[2])
+			}
+
+			if interval := receivedAt[2].Sub(receivedAt[1]); interval < 100*time.Millisecond {
+				t.Errorf("Reconnection must wait for the initial interval: %s.", interval)
+			}
+
+			err := <-errCh
+			if err != context.Canceled {
+				t.Errorf("Unexpected error is returned: %#v.", err)
+			}
+
+			// Events channel must be closed on return
+			for range supervisor.Events() {
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if connected < 2 {
+				t.Errorf("Connect hook is not called on reconnection: %d.", connected)
+			}
+			if len(disconnectErrs) == 0 || disconnectErrs[0] != ErrGoodBye {
+				t.Errorf("Disconnect hook is not called with the expected error: %#v.", disconnectErrs)
+			}
+		})
+	})
+
+	t.Run("retry with backoff", func(t *testing.T) {
+		mutex := &sync.Mutex{}
+		attempts := 0
+		expectedErr := errors.New("DUMMY")
+		supervisor := NewSupervisor(
+			func(_ context.Context) (string, error) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				attempts++
+				return "", expectedErr
+			},
+			WithBackoff(&Backoff{Initial: 10 * time.Millisecond, Max: 10 * time.Millisecond, Multiplier: 2}),
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		err := supervisor.Run(ctx)
+		if err != context.DeadlineExceeded {
+			t.Errorf("Unexpected error is returned: %#v.", err)
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		if attempts < 2 {
+			t.Errorf("Connection is not retried: %d.", attempts)
+		}
+	})
+
+	t.Run("reconnect URL", func(t *testing.T) {
+		reconnectURL := "wss://reconnect.example.com"
+		payloads := []DecodedPayload{
+			&event.ReconnectURL{TypedEvent: event.TypedEvent{Type: "reconnect_url"}, URL: reconnectURL},
+			&event.GoodBye{TypedEvent: event.TypedEvent{Type: "goodbye"}},
+		}
+		connection := &DummyConnection{
+			ReceiveFunc: func() (DecodedPayload, error) {
+				if len(payloads) == 0 {
+					return nil, errors.New("closed")
+				}
+				p := payloads[0]
+				payloads = payloads[1:]
+				return p, nil
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var urls []string
+		supervisor := NewSupervisor(
+			func(_ context.Context) (string, error) {
+				return "wss://initial.example.com", nil
+			},
+			WithConnector(func(_ context.Context, url string) (Connection, error) {
+				urls = append(urls, url)
+				if len(urls) == 2 {
+					// Stop on reconnection
+					cancel()
+				}
+				return connection, nil
+			}),
+		)
+
+		err := supervisor.Run(ctx)
+		if err != context.Canceled {
+			t.Errorf("Unexpected error is returned: %#v.", err)
+		}
+
+		if len(urls) != 2 || urls[1] != reconnectURL {
+			t.Errorf("Reconnect URL is not used: %#v.", urls)
+		}
+	})
+}
+
+func TestSupervisor_Run_Scripted(t *testing.T) {
+	golden := func(name string) string {
+		return filepath.Join("..", "testdata", "event", "decode", name)
+	}
+
+	t.Run("goodbye and abrupt disconnect", func(t *testing.T) {
+		server := testutil.NewRTMServer(
+			testutil.RTMScript{
+				testutil.SendFile(golden("hello.json.golden")),
+				testutil.SendFile(golden("message.json.golden")),
+				testutil.SendGoodbye(),
+			},
+			testutil.RTMScript{
+				testutil.SendHello(),
+				testutil.Disconnect(),
+			},
+			testutil.RTMScript{
+				testutil.SendHello(),
+			},
+		)
+		defer server.Close()
+
+		supervisor := NewSupervisor(
+			func(_ context.Context) (string, error) {
+				return server.URL(), nil
+			},
+			WithBackoff(&Backoff{Initial: 10 * time.Millisecond, Max: 10 * time.Millisecond, Multiplier: 1}),
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- supervisor.Run(ctx)
+		}()
+
+		var received []DecodedPayload
+		timeout := time.NewTimer(3 * time.Second)
+		for len(received) < 5 {
+			select {
+			case payload := <-supervisor.Events():
+				received = append(received, payload)
+
+			case <-timeout.C:
+				t.Fatalf("Expected payloads are not given: %#v.", received)
+
+			}
+		}
+		cancel()
+		<-errCh
+
+		if _, ok := received[1].(*event.Message); !ok {
+			t.Errorf("Expected message is not given: %#v.", received[1])
+		}
+		if _, ok := received[2].(*event.GoodBye); !ok {
+			t.Errorf("Expected goodbye is not given: %#v.", received[2])
+		}
+		for _, i := range []int{0, 3, 4} {
+			if _, ok := received[i].(*event.Hello); !ok {
+				t.Errorf("Expected hello is not given: %#v.", received[i])
+			}
+		}
+
+		if server.Connections() != 3 {
+			t.Errorf("Unexpected number of connections: %d.", server.Connections())
+		}
+	})
+
+	t.Run("reply", func(t *testing.T) {
+		server := testutil.NewRTMServer(testutil.RTMScript{
+			testutil.SendHello(),
+		})
+		defer server.Close()
+
+		conn, err := Connect(context.TODO(), server.URL())
+		if err != nil {
+			t.Fatalf("webSocket connection error: %s.", err.Error())
+		}
+		defer conn.Close()
+
+		// Replies are resolved while payloads are received
+		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+		defer cancel()
+		stream := Stream(ctx, conn)
+		go func() {
+			for range stream.Events() {
+			}
+		}()
+
+		pending, err := conn.SendWithReply(NewOutgoingMessage("C123", "Hello"))
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		reply, err := pending.Wait(ctx)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+		if reply.ReplyTo != pending.ID || reply.TimeStamp == nil {
+			t.Errorf("Unexpected reply is given: %#v.", reply)
+		}
+
+		select {
+		case received := <-server.Received():
+			if !strings.Contains(string(received), `"text":"Hello"`) {
+				t.Errorf("Unexpected payload is received: %s.", received)
+			}
+
+		default:
+			t.Error("Sent message is not received.")
+
+		}
+	})
+}
+
+type DummyConnection struct {
+	ReceiveFunc       func() (DecodedPayload, error)
+	SendFunc          func(OutgoingPayload) error
+	SendWithReplyFunc func(*OutgoingMessage) (*PendingReply, error)
+	PingFunc          func() error
+	CloseFunc         func() error
+}
+
+var _ Connection = (*DummyConnection)(nil)
+
+func (d *DummyConnection) Receive() (DecodedPayload, error) {
+	return d.ReceiveFunc()
+}
+
+func (d *DummyConnection) Send(payload OutgoingPayload) error {
+	return d.SendFunc(payload)
+}
+
+func (d *DummyConnection) SendWithReply(message *OutgoingMessage) (*PendingReply, error) {
+	return d.SendWithReplyFunc(message)
+}
+
+func (d *DummyConnection) Ping() error {
+	return d.PingFunc()
+}
+
+func (d *DummyConnection) Close() error {
+	if d.CloseFunc == nil {
+		return nil
+	}
+	return d.CloseFunc()
+}
@@ -0,0 +1,287 @@
+// Package socketmode provides a client for Socket Mode.
+// Socket Mode allows an app to receive events and interactive payloads over a WebSocket connection
+// instead of exposing a public HTTP endpoint.
+//
+// See https://api.slack.com/apis/connections/socket for official document.
+package socketmode
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/websocket"
+	"github.com/oklahomer/golack/v2/event"
+	"github.com/oklahomer/golack/v2/eventsapi"
+	"github.com/tidwall/gjson"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// UnexpectedMessageTypeError is returned when a WebSocket message other than text message is given.
+type UnexpectedMessageTypeError struct {
+	MessageType int
+	Payload     []byte
+}
+
+func (e *UnexpectedMessageTypeError) Error() string {
+	return fmt.Sprintf("unexpected message type, %d, is given: %s", e.MessageType, e.Payload)
+}
+
+type DecodedPayload interface{}
+
+type PayloadReceiver interface {
+	Receive() (DecodedPayload, error)
+}
+
+type Acknowledger interface {
+	// Ack acknowledges the envelope with the given ID.
+	// Give nil payload unless the envelope accepts response payload.
+	Ack(envelopeID string, payload interface{}) error
+}
+
+type Connection interface {
+	PayloadReceiver
+	Acknowledger
+	io.Closer
+}
+
+// ConnectOption changes the behavior of the connection.
+// Pass this to Connect.
+type ConnectOption func(*connectOption)
+
+// WithDialer sets the base websocket.Dialer to establish a connection.
+// websocket.DefaultDialer is used by default.
+// Other dialer-related options such as WithProxy and WithTLSConfig override the corresponding fields of the given dialer.
+func WithDialer(dialer *websocket.Dialer) ConnectOption {
+	return func(o *connectOption) {
+		o.dialer = dialer
+	}
+}
+
+// WithProxy sets a function that returns a proxy URL for the given handshake request.
+// Pass http.ProxyURL to route through a fixed proxy, or http.ProxyFromEnvironment to respect environment variables.
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) ConnectOption {
+	return func(o *connectOption) {
+		o.proxy = proxy
+	}
+}
+
+// WithTLSConfig sets the TLS configuration to use on the handshake.
+func WithTLSConfig(config *tls.Config) ConnectOption {
+	return func(o *connectOption) {
+		o.tlsConfig = config
+	}
+}
+
+// WithHandshakeTimeout sets the duration for the handshake to complete.
+// The context given to Connect is also respected during the handshake.
+func WithHandshakeTimeout(timeout time.Duration) ConnectOption {
+	return func(o *connectOption) {
+		o.handshakeTimeout = timeout
+	}
+}
+
+// WithHeader sets additional HTTP headers to be sent on the handshake.
+func WithHeader(header http.Header) ConnectOption {
+	return func(o *connectOption) {
+		o.header = header
+	}
+}
+
+type connectOption struct {
+	dialer           *websocket.Dialer
+	proxy            func(*http.Request) (*url.URL, error)
+	tlsConfig        *tls.Config
+	handshakeTimeout time.Duration
+	header           http.Header
+}
+
+// Connect connects to Slack WebSocket server.
+// The url must be the one returned by apps.connections.open method.
+// The given ctx is respected while the connection is being established, so a hung dial or handshake can be canceled.
+func Connect(ctx context.Context, url string, options ...ConnectOption) (Connection, error) {
+	opt := &connectOption{dialer: websocket.DefaultDialer}
+	for _, o := range options {
+		o(opt)
+	}
+
+	conn, _, err := opt.buildDialer().DialContext(ctx, url, opt.header)
+	if err != nil {
+		return nil, err
+	}
+
+	return newConnectionWrapper(conn), nil
+}
+
+// buildDialer returns a copy of the base dialer with the given options applied so the base dialer is never modified.
+func (o *connectOption) buildDialer() *websocket.Dialer {
+	dialer := &websocket.Dialer{}
+	if o.dialer != nil {
+		*dialer = *o.dialer
+	}
+
+	if o.proxy != nil {
+		dialer.Proxy = o.proxy
+	}
+
+	if o.tlsConfig != nil {
+		dialer.TLSClientConfig = o.tlsConfig
+	}
+
+	if o.handshakeTimeout > 0 {
+		dialer.HandshakeTimeout = o.handshakeTimeout
+	}
+
+	return dialer
+}
+
+// connWrapper is a thin wrapper that wraps WebSocket connection and its methods.
+// This instance is created per-connection.
+type connWrapper struct {
+	conn *websocket.Conn
+
+	// writeMutex serializes writes since WebSocket connection supports only one concurrent writer.
+	writeMutex *sync.Mutex
+}
+
+func newConnectionWrapper(conn *websocket.Conn) Connection {
+	return &connWrapper{
+		conn:       conn,
+		writeMutex: &sync.Mutex{},
+	}
+}
+
+// Receive is a blocking method to receive payload from WebSocket connection.
+// When connection is closed in the middle of this method call, this immediately returns error.
+func (wrapper *connWrapper) Receive() (DecodedPayload, error) {
+	messageType, payload, err := wrapper.conn.ReadMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	// Only TextMessage is supported by Socket Mode.
+	if messageType != websocket.TextMessage {
+		return nil, &UnexpectedMessageTypeError{MessageType: messageType, Payload: payload}
+	}
+
+	return decodePayload(payload)
+}
+
+// Ack sends an acknowledgement for the given envelope.
+// This is safe to call from multiple goroutines.
+func (wrapper *connWrapper) Ack(envelopeID string, payload interface{}) error {
+	wrapper.writeMutex.Lock()
+	defer wrapper.writeMutex.Unlock()
+	return wrapper.conn.WriteJSON(&Ack{
+		EnvelopeID: envelopeID,
+		Payload:    payload,
+	})
+}
+
+func (wrapper *connWrapper) Close() error {
+	return wrapper.conn.Close()
+}
+
+func decodePayload(input json.RawMessage) (DecodedPayload, error) {
+	input = bytes.TrimSpace(input)
+	if len(input) == 0 {
+		return nil, event.ErrEmptyPayload
+	}
+
+	parsed := gjson.ParseBytes(input)
+	typeValue := parsed.Get("type")
+	if !typeValue.Exists() {
+		return nil, event.NewMalformedPayloadError(fmt.Sprintf("required type field is not given: %s", input))
+	}
+
+	switch payloadType := typeValue.String(); payloadType {
+	case "hello":
+		hello := &Hello{}
+		err := json.Unmarshal(input, hello)
+		if err != nil {
+			return nil, event.NewMalformedPayloadError(fmt.Sprintf("malformed hello payload is given: %s", input))
+		}
+		return hello, nil
+
+	case "disconnect":
+		disconnect := &Disconnect{}
+		err := json.Unmarshal(input, disconnect)
+		if err != nil {
+			return nil, event.NewMalformedPayloadError(fmt.Sprintf("malformed disconnect payload is given: %s", input))
+		}
+		return disconnect, nil
+
+	case "events_api":
+		envelope, err := decodeEnvelope(input)
+		if err != nil {
+			return nil, err
+		}
+
+		decoded, err := eventsapi.DecodePayload(&eventsapi.SlackRequest{Payload: envelope.Payload})
+		if err != nil {
+			return nil, &EnvelopeDecodeError{Envelope: envelope, Err: err}
+		}
+
+		wrapper, ok := decoded.(*eventsapi.EventWrapper)
+		if !ok {
+			err := event.NewMalformedPayloadError(fmt.Sprintf("unexpected events_api payload is given: %T", decoded))
+			return nil, &EnvelopeDecodeError{Envelope: envelope, Err: err}
+		}
+
+		return &EventsAPI{
+			Envelope:     envelope,
+			EventWrapper: wrapper,
+		}, nil
+
+	case "interactive":
+		envelope, err := decodeEnvelope(input)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Interactive{
+			Envelope:        envelope,
+			InteractionType: gjson.GetBytes(envelope.Payload, "type").String(),
+		}, nil
+
+	case "slash_commands":
+		envelope, err := decodeEnvelope(input)
+		if err != nil {
+			return nil, err
+		}
+
+		command := &SlashCommandPayload{}
+		err = json.Unmarshal(envelope.Payload, command)
+		if err != nil {
+			return nil, &EnvelopeDecodeError{Envelope: envelope, Err: event.NewMalformedPayloadError(err.Error())}
+		}
+
+		return &SlashCommand{
+			Envelope: envelope,
+			Command:  command,
+		}, nil
+
+	default:
+		return nil, event.NewUnknownPayloadTypeError(fmt.Sprintf("undefined type of %s is given: %s", payloadType, input))
+
+	}
+}
+
+func decodeEnvelope(input json.RawMessage) (*Envelope, error) {
+	envelope := &Envelope{}
+	err := json.Unmarshal(input, envelope)
+	if err != nil {
+		return nil, event.NewMalformedPayloadError(fmt.Sprintf("malformed envelope is given: %s", input))
+	}
+
+	if envelope.EnvelopeID == "" {
+		return nil, event.NewMalformedPayloadError(fmt.Sprintf("required envelope_id field is not given: %s", input))
+	}
+
+	return envelope, nil
+}
@@ -0,0 +1,390 @@
+package socketmode
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gorilla/websocket"
+	"github.com/oklahomer/golack/v2/event"
+	"github.com/oklahomer/golack/v2/testutil"
+	"net"
+	"net/http"
+	neturl "net/url"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConnect(t *testing.T) {
+	testutil.RunWithWebSocket(func(addr net.Addr) {
+		url := fmt.Sprintf("ws://%s%s", addr, "/echo")
+		connection, err := Connect(context.TODO(), url)
+		if err != nil {
+			t.Fatalf("webSocket connection error: %s.", err.Error())
+		}
+
+		if connection == nil {
+			t.Fatalf("Connection is not reurned.")
+		}
+		connection.Close()
+	})
+}
+
+func TestConnect_Fail(t *testing.T) {
+	testutil.RunWithWebSocket(func(addr net.Addr) {
+		url := fmt.Sprintf("ws://%s%s", addr, "/undefined_path")
+		_, err := Connect(context.TODO(), url)
+
+		if err == nil {
+			t.Fatal("expected error is not returned.")
+		}
+
+		if err != websocket.ErrBadHandshake {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+	})
+}
+
+func TestConnect_Canceled(t *testing.T) {
+	// A server that accepts TCP connections but never completes the handshake
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %s.", err.Error())
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := Connect(ctx, fmt.Sprintf("ws://%s", listener.Addr()))
+		errCh <- err
+	}()
+
+	select {
+	case err := <-errCh:
+		if err == nil {
+			t.Fatal("Expected error is not returned.")
+		}
+
+	case <-time.NewTimer(3 * time.Second).C:
+		t.Fatal("Connect is not canceled.")
+
+	}
+}
+
+func TestConnect_Options(t *testing.T) {
+	testutil.RunWithWebSocket(func(addr net.Addr) {
+		url := fmt.Sprintf("ws://%s%s", addr, "/echo")
+		header := http.Header{}
+		header.Set("X-Custom-Header", "value")
+		var requested *http.Request
+		conn, err := Connect(
+			context.TODO(),
+			url,
+			WithHeader(header),
+			WithProxy(func(req *http.Request) (*neturl.URL, error) {
+				// Do not actually route through a proxy, but see the handshake request
+				requested = req
+				return nil, nil
+			}),
+		)
+		if err != nil {
+			t.Fatalf("webSocket connection error: %s.", err.Error())
+		}
+		defer conn.Close()
+
+		if requested == nil {
+			t.Fatal("Proxy function is not called.")
+		}
+
+		if requested.Header.Get("X-Custom-Header") != "value" {
+			t.Errorf("Expected header is not sent: %#v.", requested.Header)
+		}
+	})
+}
+
+func Test_connectOption_buildDialer(t *testing.T) {
+	base := &websocket.Dialer{
+		HandshakeTimeout: 3 * time.Second,
+		ReadBufferSize:   123,
+	}
+	tlsConfig := &tls.Config{}
+	opt := &connectOption{}
+	for _, o := range []ConnectOption{
+		WithDialer(base),
+		WithTLSConfig(tlsConfig),
+		WithHandshakeTimeout(5 * time.Second),
+	} {
+		o(opt)
+	}
+
+	dialer := opt.buildDialer()
+
+	if dialer == base {
+		t.Fatal("Base dialer must not be returned as-is.")
+	}
+
+	if dialer.ReadBufferSize != 123 {
+		t.Errorf("Setting of the base dialer is not copied: %d.", dialer.ReadBufferSize)
+	}
+
+	if dialer.TLSClientConfig != tlsConfig {
+		t.Error("Specified TLS config is not set.")
+	}
+
+	if dialer.HandshakeTimeout != 5*time.Second {
+		t.Errorf("Specified handshake timeout is not set: %s.", dialer.HandshakeTimeout)
+	}
+
+	if base.HandshakeTimeout != 3*time.Second || base.TLSClientConfig != nil {
+		t.Errorf("Base dialer is modified: %#v.", base)
+	}
+}
+
+func TestConnWrapper_Receive(t *testing.T) {
+	testutil.RunWithWebSocket(func(addr net.Addr) {
+		url := fmt.Sprintf("ws://%s%s", addr, "/socket_mode")
+		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		if err != nil {
+			t.Fatal("can't establish connection with test server")
+		}
+		defer conn.Close()
+
+		connWrapper := newConnectionWrapper(conn)
+
+		payload, err := connWrapper.Receive()
+		if err != nil {
+			t.Fatalf("error on payload reception: %s.", err.Error())
+		}
+		hello, ok := payload.(*Hello)
+		if !ok {
+			t.Fatalf("received payload is not Hello: %#v.", payload)
+		}
+		if hello.ConnectionInfo.AppID != "A0FFV41KK" {
+			t.Errorf("Expected app id is not given: %s.", hello.ConnectionInfo.AppID)
+		}
+
+		payload, err = connWrapper.Receive()
+		if err != nil {
+			t.Fatalf("error on payload reception: %s.", err.Error())
+		}
+		eventsAPI, ok := payload.(*EventsAPI)
+		if !ok {
+			t.Fatalf("received payload is not EventsAPI: %#v.", payload)
+		}
+		if eventsAPI.EnvelopeID != testutil.SocketModeEnvelopeID {
+			t.Errorf("Expected envelope id is not given: %s.", eventsAPI.EnvelopeID)
+		}
+		if _, ok := eventsAPI.EventWrapper.Event.(*event.AppMention); !ok {
+			t.Errorf("Expected event is not given: %#v.", eventsAPI.EventWrapper.Event)
+		}
+	})
+}
+
+func TestConnWrapper_Ack(t *testing.T) {
+	testutil.RunWithWebSocket(func(addr net.Addr) {
+		url := fmt.Sprintf("ws://%s%s", addr, "/echo")
+		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		if err != nil {
+			t.Fatal("can't establish connection with test server")
+		}
+		defer conn.Close()
+
+		connWrapper := newConnectionWrapper(conn)
+		err = connWrapper.Ack("envelopeID", map[string]string{"text": "Thanks"})
+		if err != nil {
+			t.Fatalf("error on sending acknowledgement over WebSocket connection. %#v.", err)
+		}
+
+		_, echoed, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("error on reading echoed message: %s.", err.Error())
+		}
+		ack := map[string]interface{}{}
+		json.Unmarshal(echoed, &ack)
+		if ack["envelope_id"] != "envelopeID" {
+			t.Errorf("Expected envelope_id is not sent: %s.", echoed)
+		}
+		if ack["payload"] == nil {
+			t.Errorf("Expected payload is not sent: %s.", echoed)
+		}
+	})
+}
+
+func TestConnWrapper_Ack_Concurrent(t *testing.T) {
+	testutil.RunWithWebSocket(func(addr net.Addr) {
+		url := fmt.Sprintf("ws://%s%s", addr, "/echo")
+		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		if err != nil {
+			t.Fatal("can't establish connection with test server")
+		}
+		defer conn.Close()
+
+		connWrapper := newConnectionWrapper(conn)
+		count := 10
+		wg := &sync.WaitGroup{}
+		for i := 0; i < count; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				err := connWrapper.Ack(strconv.Itoa(i), nil)
+				if err != nil {
+					t.Errorf("error on sending acknowledgement over WebSocket connection. %#v.", err)
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		received := map[string]bool{}
+		for i := 0; i < count; i++ {
+			_, echoed, err := conn.ReadMessage()
+			if err != nil {
+				t.Fatalf("error on reading echoed message: %s.", err.Error())
+			}
+			ack := &Ack{}
+			err = json.Unmarshal(echoed, ack)
+			if err != nil {
+				t.Fatalf("Broken acknowledgement is sent: %s.", echoed)
+			}
+			received[ack.EnvelopeID] = true
+		}
+
+		if len(received) != count {
+			t.Errorf("Unexpected acknowledgements are sent: %#v.", received)
+		}
+	})
+}
+
+func TestConnWrapper_Close(t *testing.T) {
+	testutil.RunWithWebSocket(func(addr net.Addr) {
+		url := fmt.Sprintf("ws://%s%s", addr, "/echo")
+		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		if err != nil {
+			t.Fatal("can't establish connection with test server")
+		}
+
+		connWrapper := newConnectionWrapper(conn)
+
+		if err := connWrapper.Close(); err != nil {
+			t.Fatal("error on connection close")
+		}
+
+		if err := conn.Close(); err == nil {
+			t.Fatal("net.OpError should be returned when WebSocket.Conn.Close is called multiple times.")
+		}
+	})
+}
+
+func Test_decodePayload(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected reflect.Type
+		err      interface{}
+	}{
+		{
+			input:    `{"type": "hello", "num_connections": 1, "connection_info": {"app_id": "A0FFV41KK"}}`,
+			expected: reflect.TypeOf(&Hello{}),
+		},
+		{
+			input:    `{"type": "disconnect", "reason": "refresh_requested"}`,
+			expected: reflect.TypeOf(&Disconnect{}),
+		},
+		{
+			input:    `{"type": "events_api", "envelope_id": "abc", "payload": {"type": "event_callback", "event": {"type": "hello"}}}`,
+			expected: reflect.TypeOf(&EventsAPI{}),
+		},
+		{
+			input:    `{"type": "interactive", "envelope_id": "abc", "payload": {"type": "block_actions"}}`,
+			expected: reflect.TypeOf(&Interactive{}),
+		},
+		{
+			input:    `{"type": "slash_commands", "envelope_id": "abc", "payload": {"command": "/foo", "text": "bar"}}`,
+			expected: reflect.TypeOf(&SlashCommand{}),
+		},
+		{
+			// inner event type is unknown
+			input: `{"type": "events_api", "envelope_id": "abc", "payload": {"type": "event_callback", "event": {"type": "unknown"}}}`,
+			err:   reflect.TypeOf(&EnvelopeDecodeError{}),
+		},
+		{
+			// envelope_id is missing
+			input: `{"type": "events_api", "payload": {}}`,
+			err:   reflect.TypeOf(&event.MalformedPayloadError{}),
+		},
+		{
+			input: `{"type": "unknown"}`,
+			err:   reflect.TypeOf(&event.UnknownPayloadTypeError{}),
+		},
+		{
+			input: `{"foo": "bar"}`,
+			err:   reflect.TypeOf(&event.MalformedPayloadError{}),
+		},
+		{
+			input: " ",
+			err:   event.ErrEmptyPayload,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			payload, err := decodePayload([]byte(tt.input))
+
+			if tt.expected != nil {
+				if err != nil {
+					t.Fatalf("Unexpected error is returned: %#v", err)
+				}
+
+				if reflect.TypeOf(payload) != tt.expected {
+					t.Fatalf("Expected %s but was %#v", tt.expected, payload)
+				}
+
+				return
+			}
+
+			if reflect.TypeOf(tt.err) == reflect.TypeOf(errors.New("DUMMY")) {
+				if tt.err != err {
+					t.Fatalf("Expected error is not returned: %#v", err)
+				}
+				return
+			}
+
+			if tt.err != reflect.TypeOf(err) {
+				t.Fatalf("Unexpected error type is returned: %#v.", err)
+			}
+		})
+	}
+}
+
+func Test_decodePayload_SlashCommand(t *testing.T) {
+	input := `{"type": "slash_commands", "envelope_id": "abc", "accepts_response_payload": true, "payload": {"command": "/foo", "text": "bar", "channel_id": "C123", "user_id": "U123"}}`
+	payload, err := decodePayload([]byte(input))
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+
+	command := payload.(*SlashCommand)
+	if !command.AcceptsResponsePayload {
+		t.Error("accepts_response_payload is not decoded.")
+	}
+	if command.Command.Command != "/foo" || command.Command.Text != "bar" {
+		t.Errorf("Expected command is not decoded: %#v.", command.Command)
+	}
+	if command.Command.ChannelID != "C123" || command.Command.UserID != "U123" {
+		t.Errorf("Expected IDs are not decoded: %#v.", command.Command)
+	}
+}
@@ -0,0 +1,113 @@
+package socketmode
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/oklahomer/golack/v2/event"
+	"github.com/oklahomer/golack/v2/eventsapi"
+)
+
+// Envelope contains the fields that are common to all payloads that require acknowledgement.
+// https://api.slack.com/apis/connections/socket-implement#acknowledge
+type Envelope struct {
+	Type                   string          `json:"type"`
+	EnvelopeID             string          `json:"envelope_id"`
+	Payload                json.RawMessage `json:"payload"`
+	AcceptsResponsePayload bool            `json:"accepts_response_payload"`
+	RetryAttempt           int             `json:"retry_attempt"`
+	RetryReason            string          `json:"retry_reason"`
+}
+
+// DebugInfo is given with hello and disconnect payloads to help identify the server-side state.
+type DebugInfo struct {
+	Host                      string `json:"host"`
+	Started                   string `json:"started"`
+	BuildNumber               int    `json:"build_number"`
+	ApproximateConnectionTime int    `json:"approximate_connection_time"`
+}
+
+// Hello is sent from Slack when the WebSocket connection is successfully established.
+// https://api.slack.com/apis/connections/socket-implement#connect
+type Hello struct {
+	event.TypedEvent
+	NumConnections int        `json:"num_connections"`
+	DebugInfo      *DebugInfo `json:"debug_info"`
+	ConnectionInfo *struct {
+		AppID event.AppID `json:"app_id"`
+	} `json:"connection_info"`
+}
+
+// Disconnect is sent from Slack when the current connection is about to be closed.
+// A client should establish a new connection on reception.
+// https://api.slack.com/apis/connections/socket-implement#disconnect
+type Disconnect struct {
+	event.TypedEvent
+	Reason    string     `json:"reason"`
+	DebugInfo *DebugInfo `json:"debug_info"`
+}
+
+// EventsAPI wraps a payload that is identical to what Events API sends over HTTP request.
+// EventWrapper is decoded by eventsapi.DecodePayload so existing eventsapi.EventReceiver implementations can handle it as-is.
+type EventsAPI struct {
+	*Envelope
+	EventWrapper *eventsapi.EventWrapper
+}
+
+// Interactive wraps a payload that is sent on user interaction such as block actions, shortcuts and view submissions.
+// https://api.slack.com/reference/interaction-payloads
+type Interactive struct {
+	*Envelope
+
+	// InteractionType represents the type field of the inner payload. e.g. block_actions, view_submission, shortcut
+	InteractionType string
+}
+
+// SlashCommand wraps a payload that is sent when a user invokes a slash command.
+type SlashCommand struct {
+	*Envelope
+	Command *SlashCommandPayload
+}
+
+// SlashCommandPayload represents the data Slack sends on slash command invocation.
+// https://api.slack.com/interactivity/slash-commands#app_command_handling
+type SlashCommandPayload struct {
+	Token               string          `json:"token"`
+	TeamID              event.TeamID    `json:"team_id"`
+	TeamDomain          string          `json:"team_domain"`
+	EnterpriseID        string          `json:"enterprise_id"`
+	EnterpriseName      string          `json:"enterprise_name"`
+	ChannelID           event.ChannelID `json:"channel_id"`
+	ChannelName         string          `json:"channel_name"`
+	UserID              event.UserID    `json:"user_id"`
+	UserName            string          `json:"user_name"`
+	Command             string          `json:"command"`
+	Text                string          `json:"text"`
+	APIAppID            event.AppID     `json:"api_app_id"`
+	IsEnterpriseInstall string          `json:"is_enterprise_install"`
+	ResponseURL         string          `json:"response_url"`
+	TriggerID           string          `json:"trigger_id"`
+}
+
+// Ack is sent back to Slack to acknowledge the reception of an envelope.
+// https://api.slack.com/apis/connections/socket-implement#acknowledge
+type Ack struct {
+	EnvelopeID string      `json:"envelope_id"`
+	Payload    interface{} `json:"payload,omitempty"`
+}
+
+// EnvelopeDecodeError is returned when the envelope itself is valid but its inner payload can not be decoded.
+// Envelope is still available so the caller can acknowledge the reception.
+type EnvelopeDecodeError struct {
+	Envelope *Envelope
+	Err      error
+}
+
+// Error returns its error string.
+func (e *EnvelopeDecodeError) Error() string {
+	return fmt.Sprintf("failed to decode %s payload of envelope %s: %s", e.Envelope.Type, e.Envelope.EnvelopeID, e.Err.Error())
+}
+
+// Unwrap returns the underlying error so errors.As and errors.Is can inspect it.
+func (e *EnvelopeDecodeError) Unwrap() error {
+	return e.Err
+}
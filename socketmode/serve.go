@@ -0,0 +1,145 @@
+package socketmode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/oklahomer/golack/v2/event"
+	"github.com/oklahomer/golack/v2/eventsapi"
+	"log"
+)
+
+// DisconnectedError is returned by Serve when Slack requests the client to disconnect.
+// The caller is expected to establish a new connection.
+type DisconnectedError struct {
+	Reason string
+}
+
+// Error returns its error string.
+func (e *DisconnectedError) Error() string {
+	return fmt.Sprintf("disconnect is requested by Slack: %s", e.Reason)
+}
+
+// ServeOption changes the behavior of Serve.
+type ServeOption func(*option)
+
+// WithInteractiveHandler returns a function to set given fnc on Serve.
+// The returned value of fnc is sent back to Slack as the acknowledgement payload.
+func WithInteractiveHandler(fnc func(*Interactive) interface{}) ServeOption {
+	return func(o *option) {
+		o.interactiveHandler = fnc
+	}
+}
+
+// WithSlashCommandHandler returns a function to set given fnc on Serve.
+// The returned value of fnc is sent back to Slack as the acknowledgement payload.
+func WithSlashCommandHandler(fnc func(*SlashCommand) interface{}) ServeOption {
+	return func(o *option) {
+		o.slashCommandHandler = fnc
+	}
+}
+
+type option struct {
+	interactiveHandler  func(*Interactive) interface{}
+	slashCommandHandler func(*SlashCommand) interface{}
+}
+
+// Serve receives payloads over given conn, acknowledges each envelope, and passes events to receiver.
+// This blocks until the connection fails, Slack sends a disconnect payload, or ctx is canceled.
+// On disconnect request, *DisconnectedError is returned; on ctx cancellation, ctx.Err() is returned.
+//
+// Interactive and slash command payloads are acknowledged without payload unless the corresponding handler is given.
+func Serve(ctx context.Context, conn Connection, receiver eventsapi.EventReceiver, opts ...ServeOption) error {
+	opt := &option{}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	// Close the connection on cancellation so the blocking Receive call returns
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			//noinspection ALL
+			conn.Close()
+
+		case <-done:
+			// Serve is already returning
+		}
+	}()
+
+	for {
+		payload, err := conn.Receive()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			if err == event.ErrEmptyPayload {
+				continue
+			}
+
+			var decodeErr *EnvelopeDecodeError
+			if errors.As(err, &decodeErr) {
+				// The envelope is valid so let Slack know it is received to prevent retries
+				log.Printf("Failed to decode envelope: %s", err.Error())
+				err = conn.Ack(decodeErr.Envelope.EnvelopeID, nil)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			switch err.(type) {
+			case *event.MalformedPayloadError, *event.UnknownPayloadTypeError:
+				log.Printf("Failed to decode payload: %s", err.Error())
+				continue
+
+			default:
+				return err
+
+			}
+		}
+
+		switch typed := payload.(type) {
+		case *Hello:
+			// Connection is established. Nothing to do.
+
+		case *Disconnect:
+			return &DisconnectedError{Reason: typed.Reason}
+
+		case *EventsAPI:
+			// Acknowledge first because Slack expects acknowledgement within 3 seconds
+			err = conn.Ack(typed.EnvelopeID, nil)
+			if err != nil {
+				return err
+			}
+			receiver.Receive(typed.EventWrapper)
+
+		case *Interactive:
+			var response interface{}
+			if opt.interactiveHandler != nil {
+				response = opt.interactiveHandler(typed)
+			}
+			err = conn.Ack(typed.EnvelopeID, response)
+			if err != nil {
+				return err
+			}
+
+		case *SlashCommand:
+			var response interface{}
+			if opt.slashCommandHandler != nil {
+				response = opt.slashCommandHandler(typed)
+			}
+			err = conn.Ack(typed.EnvelopeID, response)
+			if err != nil {
+				return err
+			}
+
+		default:
+			log.Printf("Successfully decoded the payload but do not know how to handle %T", typed)
+
+		}
+	}
+}
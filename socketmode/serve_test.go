@@ -0,0 +1,172 @@
+package socketmode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/oklahomer/golack/v2/eventsapi"
+	"github.com/oklahomer/golack/v2/testutil"
+	"net"
+	"testing"
+	"time"
+)
+
+type DummyConnection struct {
+	ReceiveFunc func() (DecodedPayload, error)
+	AckFunc     func(envelopeID string, payload interface{}) error
+	CloseFunc   func() error
+}
+
+func (d *DummyConnection) Receive() (DecodedPayload, error) {
+	return d.ReceiveFunc()
+}
+
+func (d *DummyConnection) Ack(envelopeID string, payload interface{}) error {
+	return d.AckFunc(envelopeID, payload)
+}
+
+func (d *DummyConnection) Close() error {
+	return d.CloseFunc()
+}
+
+func TestServe(t *testing.T) {
+	t.Run("receive and acknowledge", func(t *testing.T) {
+		testutil.RunWithWebSocket(func(addr net.Addr) {
+			url := fmt.Sprintf("ws://%s%s", addr, "/socket_mode")
+			conn, err := Connect(context.TODO(), url)
+			if err != nil {
+				t.Fatalf("webSocket connection error: %s.", err.Error())
+			}
+			defer conn.Close()
+
+			received := make(chan *eventsapi.EventWrapper, 1)
+			receiver := eventsapi.NewDefaultEventReceiver(func(wrapper *eventsapi.EventWrapper) {
+				received <- wrapper
+			})
+
+			// The test server sends disconnect on successful acknowledgement
+			err = Serve(context.TODO(), conn, receiver)
+			var disconnected *DisconnectedError
+			if !errors.As(err, &disconnected) {
+				t.Fatalf("Expected error is not returned: %#v.", err)
+			}
+			if disconnected.Reason != "warning" {
+				t.Errorf("Expected reason is not given: %s.", disconnected.Reason)
+			}
+
+			select {
+			case wrapper := <-received:
+				if wrapper.TeamID != "T061EG9RZ" {
+					t.Errorf("Expected team ID is not given: %s.", wrapper.TeamID)
+				}
+
+			default:
+				t.Error("Event is not passed to receiver.")
+			}
+		})
+	})
+
+	t.Run("interactive and slash command handlers", func(t *testing.T) {
+		payloads := []DecodedPayload{
+			&Interactive{Envelope: &Envelope{EnvelopeID: "interactive"}},
+			&SlashCommand{Envelope: &Envelope{EnvelopeID: "slash_commands"}, Command: &SlashCommandPayload{}},
+			&Disconnect{},
+		}
+		acks := map[string]interface{}{}
+		conn := &DummyConnection{
+			ReceiveFunc: func() (DecodedPayload, error) {
+				p := payloads[0]
+				payloads = payloads[1:]
+				return p, nil
+			},
+			AckFunc: func(envelopeID string, payload interface{}) error {
+				acks[envelopeID] = payload
+				return nil
+			},
+			CloseFunc: func() error {
+				return nil
+			},
+		}
+
+		err := Serve(
+			context.TODO(),
+			conn,
+			eventsapi.NewDefaultEventReceiver(func(_ *eventsapi.EventWrapper) {}),
+			WithInteractiveHandler(func(_ *Interactive) interface{} { return "interactive response" }),
+			WithSlashCommandHandler(func(_ *SlashCommand) interface{} { return "command response" }),
+		)
+		if _, ok := err.(*DisconnectedError); !ok {
+			t.Fatalf("Unexpected error is returned: %#v.", err)
+		}
+
+		if acks["interactive"] != "interactive response" {
+			t.Errorf("Expected interactive response is not sent: %#v.", acks["interactive"])
+		}
+		if acks["slash_commands"] != "command response" {
+			t.Errorf("Expected command response is not sent: %#v.", acks["slash_commands"])
+		}
+	})
+
+	t.Run("acknowledge undecodable envelope", func(t *testing.T) {
+		expectedErr := errors.New("DUMMY")
+		errs := []error{
+			&EnvelopeDecodeError{Envelope: &Envelope{EnvelopeID: "abc"}, Err: errors.New("decode error")},
+			expectedErr,
+		}
+		acked := ""
+		conn := &DummyConnection{
+			ReceiveFunc: func() (DecodedPayload, error) {
+				err := errs[0]
+				errs = errs[1:]
+				return nil, err
+			},
+			AckFunc: func(envelopeID string, _ interface{}) error {
+				acked = envelopeID
+				return nil
+			},
+			CloseFunc: func() error {
+				return nil
+			},
+		}
+
+		err := Serve(context.TODO(), conn, eventsapi.NewDefaultEventReceiver(func(_ *eventsapi.EventWrapper) {}))
+		if err != expectedErr {
+			t.Fatalf("Unexpected error is returned: %#v.", err)
+		}
+
+		if acked != "abc" {
+			t.Errorf("Envelope is not acknowledged: %s.", acked)
+		}
+	})
+
+	t.Run("context cancellation", func(t *testing.T) {
+		closed := make(chan struct{})
+		conn := &DummyConnection{
+			ReceiveFunc: func() (DecodedPayload, error) {
+				<-closed
+				return nil, errors.New("connection closed")
+			},
+			CloseFunc: func() error {
+				close(closed)
+				return nil
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- Serve(ctx, conn, eventsapi.NewDefaultEventReceiver(func(_ *eventsapi.EventWrapper) {}))
+		}()
+		cancel()
+
+		select {
+		case err := <-errCh:
+			if err != context.Canceled {
+				t.Errorf("Unexpected error is returned: %#v.", err)
+			}
+
+		case <-time.NewTimer(1 * time.Second).C:
+			t.Fatal("Serve did not return on context cancellation.")
+		}
+	})
+}
@@ -0,0 +1,473 @@
+// Package state provides an in-memory store that represents the current state of a Slack workspace.
+// The store is built from the snapshot returned by rtm.start method, and is kept up-to-date by applying events received over RTM connection.
+//
+//	store := state.NewStore(rtmStart)
+//	stream := rtmapi.Stream(ctx, conn)
+//	for payload := range stream.Events() {
+//	    store.Apply(payload)
+//	}
+//
+// All methods are safe for concurrent use.
+// Returned values are copies, so modifying them does not affect the stored state.
+package state
+
+import (
+	"encoding/json"
+	"github.com/oklahomer/golack/v2/event"
+	"github.com/oklahomer/golack/v2/webapi"
+	"strings"
+	"sync"
+)
+
+// Channel represents the state of a public channel, a private channel or a direct message.
+type Channel struct {
+	ID        event.ChannelID
+	Name      string
+	CreatorID event.UserID
+
+	// IsPrivate is true for a private channel that used to be called "group."
+	IsPrivate bool
+
+	// IsIM is true for a direct message. UserID is the other party of the conversation.
+	IsIM   bool
+	UserID event.UserID
+
+	IsArchived bool
+	IsMember   bool
+	IsOpen     bool
+	Topic      string
+	Purpose    string
+	MemberIDs  []event.UserID
+}
+
+func (c *Channel) copy() *Channel {
+	copied := *c
+	copied.MemberIDs = append([]event.UserID(nil), c.MemberIDs...)
+	return &copied
+}
+
+func (c *Channel) addMember(userID event.UserID) {
+	if userID == "" {
+		return
+	}
+
+	for _, id := range c.MemberIDs {
+		if id == userID {
+			return
+		}
+	}
+	c.MemberIDs = append(c.MemberIDs, userID)
+}
+
+func (c *Channel) removeMember(userID event.UserID) {
+	for i, id := range c.MemberIDs {
+		if id == userID {
+			c.MemberIDs = append(c.MemberIDs[:i], c.MemberIDs[i+1:]...)
+			return
+		}
+	}
+}
+
+// Store holds the state of a workspace.
+// Use NewStore to initialize with the rtm.start snapshot, and call Apply with every received event to keep it current.
+type Store struct {
+	self     webapi.Self
+	team     webapi.Team
+	users    map[event.UserID]*event.User
+	bots     map[event.BotID]*event.Bot
+	channels map[event.ChannelID]*Channel
+	mutex    *sync.RWMutex
+}
+
+// NewStore creates a new Store with the given snapshot.
+// The snapshot is typically a response of rtm.start method.
+func NewStore(rtmStart *webapi.RTMStart) *Store {
+	s := &Store{
+		users:    map[event.UserID]*event.User{},
+		bots:     map[event.BotID]*event.Bot{},
+		channels: map[event.ChannelID]*Channel{},
+		mutex:    &sync.RWMutex{},
+	}
+
+	if rtmStart.Self != nil {
+		s.self = *rtmStart.Self
+	}
+
+	if rtmStart.Team != nil {
+		s.team = *rtmStart.Team
+	}
+
+	for i := range rtmStart.Users {
+		user := newUser(&rtmStart.Users[i])
+		if user == nil {
+			continue
+		}
+		s.users[user.ID] = user
+	}
+
+	for _, b := range rtmStart.Bots {
+		s.bots[event.BotID(b.ID)] = &event.Bot{
+			ID:   event.BotID(b.ID),
+			Name: b.Name,
+			Icon: &event.BotIcon{Image48: b.Icons.Image48},
+		}
+	}
+
+	for _, c := range rtmStart.Channels {
+		s.channels[event.ChannelID(c.ID)] = &Channel{
+			ID:         event.ChannelID(c.ID),
+			Name:       c.Name,
+			CreatorID:  event.UserID(c.Creator),
+			IsArchived: c.IsArchived,
+			IsMember:   c.IsMember,
+			IsOpen:     c.IsOpen,
+			Topic:      c.Topic.Value,
+			Purpose:    c.Purpose.Value,
+			MemberIDs:  toUserIDs(c.Members),
+		}
+	}
+
+	for _, g := range rtmStart.Groups {
+		s.channels[event.ChannelID(g.ID)] = &Channel{
+			ID:         event.ChannelID(g.ID),
+			Name:       g.Name,
+			CreatorID:  event.UserID(g.Creator),
+			IsPrivate:  true,
+			IsArchived: g.IsArchived,
+			IsMember:   true, // Only the groups that the user is a member of are listed
+			IsOpen:     g.IsOpen,
+			Topic:      g.Topic.Value,
+			Purpose:    g.Purpose.Value,
+			MemberIDs:  toUserIDs(g.Members),
+		}
+	}
+
+	for _, im := range rtmStart.IMs {
+		s.channels[event.ChannelID(im.ID)] = &Channel{
+			ID:       event.ChannelID(im.ID),
+			IsIM:     true,
+			UserID:   event.UserID(im.UserID),
+			IsMember: true,
+			IsOpen:   im.IsOpen,
+		}
+	}
+
+	return s
+}
+
+// Apply updates the state with the given event.
+// Events that do not affect the state are simply ignored, so every received payload can be passed.
+func (s *Store) Apply(payload interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch typed := payload.(type) {
+	case *event.ChannelCreated:
+		if typed.Channel == nil {
+			return
+		}
+		s.channels[typed.Channel.ID] = &Channel{
+			ID:        typed.Channel.ID,
+			Name:      typed.Channel.Name,
+			CreatorID: typed.Channel.CreatorID,
+			IsOpen:    true,
+		}
+
+	case *event.ChannelRenamed:
+		if typed.Channel == nil {
+			return
+		}
+		s.channel(typed.Channel.ID).Name = typed.Channel.Name
+
+	case *event.GroupRenamed:
+		if typed.Channel == nil {
+			return
+		}
+		s.channel(typed.Channel.ID).Name = typed.Channel.Name
+
+	case *event.ChannelArchived:
+		s.channel(typed.ChannelID).IsArchived = true
+
+	case *event.GroupArchived:
+		s.channel(typed.ChannelID).IsArchived = true
+
+	case *event.ChannelUnarchived:
+		s.channel(typed.ChannelID).IsArchived = false
+
+	case *event.GroupUnarchived:
+		s.channel(typed.ChannelID).IsArchived = false
+
+	case *event.ChannelDeleted:
+		delete(s.channels, typed.ChannelID)
+
+	case *event.GroupDeleted:
+		delete(s.channels, typed.ChannelID)
+
+	case *event.ChannelJoined:
+		s.join(typed.ChannelID)
+
+	case *event.GroupJoined:
+		channel := s.join(typed.ChannelID)
+		channel.IsPrivate = true
+
+	case *event.ChannelLeft:
+		s.leave(typed.ChannelID)
+
+	case *event.GroupLeft:
+		s.leave(typed.ChannelID)
+
+	case *event.MemberJoinedChannel:
+		s.channel(typed.ChannelID).addMember(typed.UserID)
+
+	case *event.MemberLeftChannel:
+		s.channel(typed.ChannelID).removeMember(typed.UserID)
+
+	case *event.IMCreated:
+		if typed.Channel == nil {
+			return
+		}
+		channel := s.channel(typed.Channel.ID)
+		channel.IsIM = true
+		channel.UserID = typed.UserID
+		channel.IsMember = true
+
+	case *event.IMOpened:
+		channel := s.channel(typed.ChannelID)
+		channel.IsIM = true
+		channel.UserID = typed.UserID
+		channel.IsOpen = true
+
+	case *event.IMClosed:
+		s.channel(typed.ChannelID).IsOpen = false
+
+	case *event.TeamJoined:
+		s.putUser(typed.User)
+
+	case *event.UserChanged:
+		s.putUser(typed.User)
+
+	case *event.BotAdded:
+		s.putBot(typed.Bot)
+
+	case *event.BotChanged:
+		s.putBot(typed.Bot)
+
+	case *event.TeamRenamed:
+		s.team.Name = typed.Name
+
+	case *event.TeamDomainChanged:
+		s.team.Domain = typed.Domain
+
+	}
+}
+
+// Self returns the authenticated user.
+func (s *Store) Self() *webapi.Self {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	self := s.self
+	return &self
+}
+
+// Team returns the workspace.
+func (s *Store) Team() *webapi.Team {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	team := s.team
+	return &team
+}
+
+// User returns the user with the given ID.
+func (s *Store) User(id event.UserID) (*event.User, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	user, ok := s.users[id]
+	if !ok {
+		return nil, false
+	}
+
+	return copyUser(user), true
+}
+
+// UserByName returns the user with the given name.
+// A leading "@" is ignored.
+func (s *Store) UserByName(name string) (*event.User, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	name = strings.TrimPrefix(name, "@")
+	for _, user := range s.users {
+		if user.Name == name {
+			return copyUser(user), true
+		}
+	}
+
+	return nil, false
+}
+
+// Users returns all known users.
+func (s *Store) Users() []*event.User {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	users := make([]*event.User, 0, len(s.users))
+	for _, user := range s.users {
+		users = append(users, copyUser(user))
+	}
+	return users
+}
+
+// Bot returns the bot with the given ID.
+func (s *Store) Bot(id event.BotID) (*event.Bot, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	bot, ok := s.bots[id]
+	if !ok {
+		return nil, false
+	}
+
+	return copyBot(bot), true
+}
+
+// Channel returns the channel, private channel or direct message with the given ID.
+func (s *Store) Channel(id event.ChannelID) (*Channel, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	channel, ok := s.channels[id]
+	if !ok {
+		return nil, false
+	}
+
+	return channel.copy(), true
+}
+
+// ChannelByName returns the public or private channel with the given name.
+// A leading "#" is ignored.
+func (s *Store) ChannelByName(name string) (*Channel, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	name = strings.TrimPrefix(name, "#")
+	for _, channel := range s.channels {
+		if !channel.IsIM && channel.Name == name {
+			return channel.copy(), true
+		}
+	}
+
+	return nil, false
+}
+
+// IMByUser returns the direct message with the given user.
+func (s *Store) IMByUser(userID event.UserID) (*Channel, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for _, channel := range s.channels {
+		if channel.IsIM && channel.UserID == userID {
+			return channel.copy(), true
+		}
+	}
+
+	return nil, false
+}
+
+// Members returns the IDs of the users in the given channel.
+// Nil is returned when the channel is not known.
+func (s *Store) Members(channelID event.ChannelID) []event.UserID {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	channel, ok := s.channels[channelID]
+	if !ok {
+		return nil
+	}
+
+	return append([]event.UserID{}, channel.MemberIDs...)
+}
+
+// channel returns the stored channel with the given ID.
+// When the channel is not known yet, an empty one is created so the following events are not lost.
+func (s *Store) channel(id event.ChannelID) *Channel {
+	channel, ok := s.channels[id]
+	if !ok {
+		channel = &Channel{ID: id}
+		s.channels[id] = channel
+	}
+	return channel
+}
+
+func (s *Store) join(channelID event.ChannelID) *Channel {
+	channel := s.channel(channelID)
+	channel.IsMember = true
+	channel.IsOpen = true
+	channel.addMember(event.UserID(s.self.ID))
+	return channel
+}
+
+func (s *Store) leave(channelID event.ChannelID) {
+	channel := s.channel(channelID)
+	channel.IsMember = false
+	channel.removeMember(event.UserID(s.self.ID))
+}
+
+func (s *Store) putUser(user *event.User) {
+	if user == nil || user.ID == "" {
+		return
+	}
+
+	s.users[user.ID] = copyUser(user)
+}
+
+func (s *Store) putBot(bot *event.Bot) {
+	if bot == nil || bot.ID == "" {
+		return
+	}
+
+	s.bots[bot.ID] = copyBot(bot)
+}
+
+func copyUser(user *event.User) *event.User {
+	copied := *user
+	if user.Profile != nil {
+		profile := *user.Profile
+		copied.Profile = &profile
+	}
+	return &copied
+}
+
+func copyBot(bot *event.Bot) *event.Bot {
+	copied := *bot
+	if bot.Icon != nil {
+		icon := *bot.Icon
+		copied.Icon = &icon
+	}
+	return &copied
+}
+
+// newUser converts the user representation of Web API to that of event.
+// Both share the same JSON structure, so the conversion is done via JSON to copy the nested profile as well.
+func newUser(user *webapi.User) *event.User {
+	b, err := json.Marshal(user)
+	if err != nil {
+		return nil
+	}
+
+	converted := &event.User{}
+	err = json.Unmarshal(b, converted)
+	if err != nil || converted.ID == "" {
+		return nil
+	}
+
+	return converted
+}
+
+func toUserIDs(ids []string) []event.UserID {
+	userIDs := make([]event.UserID, len(ids))
+	for i, id := range ids {
+		userIDs[i] = event.UserID(id)
+	}
+	return userIDs
+}
@@ -0,0 +1,267 @@
+package state
+
+import (
+	"encoding/json"
+	"github.com/oklahomer/golack/v2/event"
+	"github.com/oklahomer/golack/v2/webapi"
+	"sync"
+	"testing"
+)
+
+const rtmStartJSON = `{
+  "ok": true,
+  "url": "wss://example.com/",
+  "self": {"id": "U023BECGF", "name": "bobby"},
+  "team": {"id": "T024BE7LD", "name": "Example", "domain": "example"},
+  "users": [
+    {"id": "U023BECGF", "name": "bobby", "profile": {"real_name": "Bobby Tables"}},
+    {"id": "U061F7AUR", "name": "alice"}
+  ],
+  "channels": [
+    {"id": "C024BE91L", "name": "general", "is_member": true, "members": ["U023BECGF", "U061F7AUR"], "topic": {"value": "Fun times"}}
+  ],
+  "groups": [
+    {"id": "G024BE91L", "name": "secret", "members": ["U023BECGF"]}
+  ],
+  "bots": [
+    {"id": "B024BE7LH", "name": "hugbot", "icons": {"image_48": "https://example.com/bot.png"}}
+  ],
+  "ims": [
+    {"id": "D024BFF1M", "user": "U061F7AUR", "is_open": true}
+  ]
+}`
+
+func newRTMStart(t *testing.T) *webapi.RTMStart {
+	rtmStart := &webapi.RTMStart{}
+	err := json.Unmarshal([]byte(rtmStartJSON), rtmStart)
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+	return rtmStart
+}
+
+func TestNewStore(t *testing.T) {
+	store := NewStore(newRTMStart(t))
+
+	if store.Self().ID != "U023BECGF" {
+		t.Errorf("Unexpected self is stored: %#v.", store.Self())
+	}
+
+	if store.Team().Domain != "example" {
+		t.Errorf("Unexpected team is stored: %#v.", store.Team())
+	}
+
+	user, ok := store.User("U023BECGF")
+	if !ok {
+		t.Fatal("User is not stored.")
+	}
+	if user.Profile == nil || user.Profile.RealName != "Bobby Tables" {
+		t.Errorf("Profile is not stored: %#v.", user.Profile)
+	}
+
+	if len(store.Users()) != 2 {
+		t.Errorf("Unexpected number of users are stored: %d.", len(store.Users()))
+	}
+
+	bot, ok := store.Bot("B024BE7LH")
+	if !ok || bot.Icon.Image48 != "https://example.com/bot.png" {
+		t.Errorf("Bot is not stored: %#v.", bot)
+	}
+
+	channel, ok := store.ChannelByName("#general")
+	if !ok {
+		t.Fatal("Channel is not stored.")
+	}
+	if channel.ID != "C024BE91L" || channel.Topic != "Fun times" || !channel.IsMember {
+		t.Errorf("Unexpected channel is stored: %#v.", channel)
+	}
+
+	group, ok := store.Channel("G024BE91L")
+	if !ok || !group.IsPrivate {
+		t.Errorf("Private channel is not stored: %#v.", group)
+	}
+
+	im, ok := store.IMByUser("U061F7AUR")
+	if !ok || im.ID != "D024BFF1M" {
+		t.Errorf("Direct message is not stored: %#v.", im)
+	}
+
+	members := store.Members("C024BE91L")
+	if len(members) != 2 {
+		t.Errorf("Unexpected members are stored: %#v.", members)
+	}
+}
+
+func TestStore_Apply(t *testing.T) {
+	t.Run("channel", func(t *testing.T) {
+		store := NewStore(newRTMStart(t))
+
+		created := &event.ChannelCreated{}
+		created.Channel = &struct {
+			ID        event.ChannelID  `json:"id"`
+			Name      string           `json:"name"`
+			Created   *event.TimeStamp `json:"created"`
+			CreatorID event.UserID     `json:"creator"`
+		}{ID: "C123", Name: "new", CreatorID: "U061F7AUR"}
+		store.Apply(created)
+
+		channel, ok := store.ChannelByName("new")
+		if !ok || channel.CreatorID != "U061F7AUR" {
+			t.Fatalf("Created channel is not stored: %#v.", channel)
+		}
+
+		store.Apply(&event.ChannelJoined{ChannelID: "C123"})
+		store.Apply(&event.MemberJoinedChannel{ChannelID: "C123", UserID: "U061F7AUR"})
+		members := store.Members("C123")
+		if len(members) != 2 || members[0] != "U023BECGF" || members[1] != "U061F7AUR" {
+			t.Errorf("Unexpected members are stored: %#v.", members)
+		}
+
+		store.Apply(&event.MemberLeftChannel{ChannelID: "C123", UserID: "U061F7AUR"})
+		store.Apply(&event.ChannelArchived{ChannelID: "C123"})
+		channel, _ = store.Channel("C123")
+		if !channel.IsArchived || !channel.IsMember || len(channel.MemberIDs) != 1 {
+			t.Errorf("Channel is not updated: %#v.", channel)
+		}
+
+		renamed := &event.ChannelRenamed{}
+		renamed.Channel = &struct {
+			ID      event.ChannelID  `json:"id"`
+			Name    string           `json:"name"`
+			Created *event.TimeStamp `json:"created"`
+		}{ID: "C123", Name: "renamed"}
+		store.Apply(renamed)
+		if _, ok := store.ChannelByName("new"); ok {
+			t.Error("Old name is still returned.")
+		}
+		if _, ok := store.ChannelByName("renamed"); !ok {
+			t.Error("Channel is not renamed.")
+		}
+
+		store.Apply(&event.ChannelLeft{ChannelID: "C123"})
+		channel, _ = store.Channel("C123")
+		if channel.IsMember || len(channel.MemberIDs) != 0 {
+			t.Errorf("Channel is not left: %#v.", channel)
+		}
+
+		store.Apply(&event.ChannelDeleted{ChannelID: "C123"})
+		if _, ok := store.Channel("C123"); ok {
+			t.Error("Deleted channel is still stored.")
+		}
+	})
+
+	t.Run("direct message", func(t *testing.T) {
+		store := NewStore(newRTMStart(t))
+
+		created := &event.IMCreated{UserID: "U999"}
+		created.Channel = &struct {
+			ID event.ChannelID `json:"id"`
+		}{ID: "D999"}
+		store.Apply(created)
+		store.Apply(&event.IMOpened{ChannelID: "D999", UserID: "U999"})
+
+		im, ok := store.IMByUser("U999")
+		if !ok || im.ID != "D999" || !im.IsOpen {
+			t.Fatalf("Direct message is not stored: %#v.", im)
+		}
+
+		store.Apply(&event.IMClosed{ChannelID: "D999", UserID: "U999"})
+		im, _ = store.Channel("D999")
+		if im.IsOpen {
+			t.Errorf("Direct message is not closed: %#v.", im)
+		}
+	})
+
+	t.Run("user", func(t *testing.T) {
+		store := NewStore(newRTMStart(t))
+
+		store.Apply(&event.TeamJoined{User: &event.User{ID: "U999", Name: "new"}})
+		if _, ok := store.UserByName("@new"); !ok {
+			t.Error("Joined user is not stored.")
+		}
+
+		store.Apply(&event.UserChanged{User: &event.User{ID: "U999", Name: "changed"}})
+		user, _ := store.User("U999")
+		if user.Name != "changed" {
+			t.Errorf("User is not updated: %#v.", user)
+		}
+	})
+
+	t.Run("bot", func(t *testing.T) {
+		store := NewStore(newRTMStart(t))
+
+		store.Apply(&event.BotAdded{Bot: &event.Bot{ID: "B999", Name: "new"}})
+		store.Apply(&event.BotChanged{Bot: &event.Bot{ID: "B999", Name: "changed"}})
+		bot, ok := store.Bot("B999")
+		if !ok || bot.Name != "changed" {
+			t.Errorf("Bot is not updated: %#v.", bot)
+		}
+	})
+
+	t.Run("team", func(t *testing.T) {
+		store := NewStore(newRTMStart(t))
+
+		store.Apply(&event.TeamRenamed{Name: "renamed"})
+		store.Apply(&event.TeamDomainChanged{Domain: "new-domain"})
+		team := store.Team()
+		if team.Name != "renamed" || team.Domain != "new-domain" {
+			t.Errorf("Team is not updated: %#v.", team)
+		}
+	})
+
+	t.Run("irrelevant event", func(t *testing.T) {
+		store := NewStore(newRTMStart(t))
+		store.Apply(&event.Hello{})
+		store.Apply(nil)
+	})
+}
+
+func TestStore_copy(t *testing.T) {
+	store := NewStore(newRTMStart(t))
+
+	channel, _ := store.Channel("C024BE91L")
+	channel.Name = "modified"
+	channel.MemberIDs[0] = "modified"
+
+	user, _ := store.User("U023BECGF")
+	user.Profile.RealName = "modified"
+
+	stored, _ := store.Channel("C024BE91L")
+	if stored.Name != "general" || stored.MemberIDs[0] != "U023BECGF" {
+		t.Errorf("Stored channel is modified: %#v.", stored)
+	}
+
+	storedUser, _ := store.User("U023BECGF")
+	if storedUser.Profile.RealName != "Bobby Tables" {
+		t.Errorf("Stored user is modified: %#v.", storedUser.Profile)
+	}
+}
+
+func TestStore_concurrency(t *testing.T) {
+	store := NewStore(newRTMStart(t))
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				store.Apply(&event.MemberJoinedChannel{ChannelID: "C024BE91L", UserID: "U999"})
+				store.Apply(&event.MemberLeftChannel{ChannelID: "C024BE91L", UserID: "U999"})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				store.Members("C024BE91L")
+				store.ChannelByName("general")
+				store.Users()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(store.Members("C024BE91L")) != 2 {
+		t.Errorf("Unexpected members are stored: %#v.", store.Members("C024BE91L"))
+	}
+}
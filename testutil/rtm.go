@@ -0,0 +1,260 @@
+package testutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/websocket"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RTMStep is a single step of RTMScript.
+// A step returns an error to stop the script and close the connection.
+type RTMStep func(conn *RTMServerConn) error
+
+// RTMScript is a sequence of steps that RTMServer runs on each connection.
+type RTMScript []RTMStep
+
+// SendEvent sends the given JSON payload as-is.
+func SendEvent(payload string) RTMStep {
+	return func(conn *RTMServerConn) error {
+		return conn.Write([]byte(payload))
+	}
+}
+
+// SendFile reads the given file such as a golden file under testdata directory and sends its content as a payload.
+func SendFile(path string) RTMStep {
+	return func(conn *RTMServerConn) error {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %s", path, err.Error())
+		}
+		return conn.Write(b)
+	}
+}
+
+// SendHello sends hello event.
+func SendHello() RTMStep {
+	return SendEvent(`{"type": "hello"}`)
+}
+
+// SendGoodbye sends goodbye event to tell the client that the server is about to close the connection.
+// Unlike Slack, the connection is kept open until the client closes it or Disconnect step follows.
+func SendGoodbye() RTMStep {
+	return SendEvent(`{"type": "goodbye"}`)
+}
+
+// Sleep waits for the given duration before proceeding to the next step.
+func Sleep(d time.Duration) RTMStep {
+	return func(_ *RTMServerConn) error {
+		time.Sleep(d)
+		return nil
+	}
+}
+
+// WaitForMessages waits until the client sends the given number of messages on this connection.
+// Pings are not counted.
+func WaitForMessages(count int, timeout time.Duration) RTMStep {
+	return func(conn *RTMServerConn) error {
+		deadline := time.Now().Add(timeout)
+		for time.Now().Before(deadline) {
+			if conn.messageCount() >= count {
+				return nil
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		return fmt.Errorf("%d messages are not given in %s", count, timeout)
+	}
+}
+
+// Disconnect abruptly closes the underlying network connection without a close frame.
+func Disconnect() RTMStep {
+	return func(conn *RTMServerConn) error {
+		return conn.conn.UnderlyingConn().Close()
+	}
+}
+
+// RTMServerConn represents a connection established with RTMServer.
+type RTMServerConn struct {
+	conn       *websocket.Conn
+	writeMutex *sync.Mutex
+	received   int
+	mutex      *sync.Mutex
+}
+
+// Write sends the given payload to the client.
+func (c *RTMServerConn) Write(payload []byte) error {
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+	return c.conn.WriteMessage(websocket.TextMessage, payload)
+}
+
+func (c *RTMServerConn) messageCount() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.received
+}
+
+func (c *RTMServerConn) countMessage() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.received++
+}
+
+// RTMServer is a fake Slack RTM server for scripted integration tests.
+// In addition to running the given scripts, the server behaves as Slack does:
+//   - ping is answered with pong that has the corresponding reply_to field
+//   - message is replied with ok, reply_to and ts fields
+//   - message with empty text is replied with an error
+type RTMServer struct {
+	server      *httptest.Server
+	scripts     []RTMScript
+	received    chan json.RawMessage
+	connections int
+	ts          int
+	mutex       *sync.Mutex
+}
+
+// NewRTMServer starts a new RTMServer.
+// The i-th connection runs the i-th script, and the last script is used for any subsequent connection.
+// This enables tests of reconnection where the first connection sends goodbye and the second one behaves differently.
+// Call Close to stop the server.
+func NewRTMServer(scripts ...RTMScript) *RTMServer {
+	s := &RTMServer{
+		scripts:  scripts,
+		received: make(chan json.RawMessage, 100),
+		mutex:    &sync.Mutex{},
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// URL returns the WebSocket URL to connect to.
+func (s *RTMServer) URL() string {
+	return "ws" + strings.TrimPrefix(s.server.URL, "http")
+}
+
+// Received returns a channel that passes every payload sent by clients except pings.
+// When the buffer is full, subsequent payloads are discarded.
+func (s *RTMServer) Received() <-chan json.RawMessage {
+	return s.received
+}
+
+// Connections returns the number of connections established so far.
+func (s *RTMServer) Connections() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.connections
+}
+
+// Close stops the server and closes all connections.
+func (s *RTMServer) Close() {
+	s.server.CloseClientConnections()
+	s.server.Close()
+}
+
+func (s *RTMServer) serve(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{}
+	c, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		panic(fmt.Errorf("failed to upgrade protocol: %s", err.Error()))
+	}
+	defer c.Close()
+
+	conn := &RTMServerConn{
+		conn:       c,
+		writeMutex: &sync.Mutex{},
+		mutex:      &sync.Mutex{},
+	}
+
+	script := s.nextScript()
+	go func() {
+		for _, step := range script {
+			err := step(conn)
+			if err != nil {
+				//noinspection ALL
+				c.Close()
+				return
+			}
+		}
+	}()
+
+	for {
+		_, message, err := c.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		err = s.respond(conn, message)
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (s *RTMServer) nextScript() RTMScript {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.connections++
+	if len(s.scripts) == 0 {
+		return nil
+	}
+
+	i := s.connections - 1
+	if i >= len(s.scripts) {
+		i = len(s.scripts) - 1
+	}
+	return s.scripts[i]
+}
+
+func (s *RTMServer) respond(conn *RTMServerConn, message []byte) error {
+	payload := &struct {
+		Type string `json:"type"`
+		ID   uint   `json:"id"`
+		Text string `json:"text"`
+	}{}
+	err := json.Unmarshal(message, payload)
+	if err != nil {
+		// Not a valid payload. Ignore.
+		return nil
+	}
+
+	if payload.Type == "ping" {
+		pong := fmt.Sprintf(`{"type": "pong", "reply_to": %d, "time": %d}`, payload.ID, time.Now().Unix())
+		return conn.Write([]byte(pong))
+	}
+
+	conn.countMessage()
+	select {
+	case s.received <- json.RawMessage(message):
+		// O.K.
+
+	default:
+		// Discard
+
+	}
+
+	if payload.Type != "message" || payload.ID == 0 {
+		return nil
+	}
+
+	var reply string
+	if payload.Text == "" {
+		reply = fmt.Sprintf(`{"ok": false, "reply_to": %d, "error": {"code": 2, "msg": "message text is missing"}}`, payload.ID)
+	} else {
+		reply = fmt.Sprintf(`{"ok": true, "reply_to": %d, "ts": "%s", "text": %q}`, payload.ID, s.nextTimeStamp(), payload.Text)
+	}
+	return conn.Write([]byte(reply))
+}
+
+func (s *RTMServer) nextTimeStamp() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.ts++
+	return fmt.Sprintf("%d.%06d", time.Now().Unix(), s.ts)
+}
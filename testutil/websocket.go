@@ -1,6 +1,7 @@
 package testutil
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/gorilla/websocket"
 	"net"
@@ -49,11 +50,173 @@ func pingServer(w http.ResponseWriter, r *http.Request) {
 	c.WriteJSON(res)
 }
 
+// pongServer replies to every ping with the corresponding pong.
+func pongServer(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{}
+	c, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		panic(fmt.Errorf("failed to upgrade protocol: %s", err.Error()))
+	}
+	defer c.Close()
+
+	for {
+		ping := &struct {
+			Type string `json:"type"`
+			ID   uint   `json:"id"`
+		}{}
+		err := c.ReadJSON(ping)
+		if err != nil {
+			return
+		}
+
+		if ping.Type != "ping" {
+			continue
+		}
+
+		err = c.WriteJSON(&Pong{Type: "pong", ReplyTo: ping.ID})
+		if err != nil {
+			return
+		}
+	}
+}
+
+// replyServer replies to every message as Slack does.
+// A message with empty text is replied with an error to simulate a failed operation.
+func replyServer(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{}
+	c, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		panic(fmt.Errorf("failed to upgrade protocol: %s", err.Error()))
+	}
+	defer c.Close()
+
+	for {
+		message := &struct {
+			Type string `json:"type"`
+			ID   uint   `json:"id"`
+			Text string `json:"text"`
+		}{}
+		err := c.ReadJSON(message)
+		if err != nil {
+			return
+		}
+
+		var reply string
+		if message.Text == "" {
+			reply = fmt.Sprintf(`{"ok": false, "reply_to": %d, "error": {"code": 2, "msg": "message text is missing"}}`, message.ID)
+		} else {
+			reply = fmt.Sprintf(`{"ok": true, "reply_to": %d, "ts": "1355517523.%06d", "text": %q}`, message.ID, message.ID, message.Text)
+		}
+
+		err = c.WriteMessage(websocket.TextMessage, []byte(reply))
+		if err != nil {
+			return
+		}
+	}
+}
+
+// goodbyeServer sends hello and goodbye events, and then waits for the client to close the connection.
+func goodbyeServer(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{}
+	c, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		panic(fmt.Errorf("failed to upgrade protocol: %s", err.Error()))
+	}
+	defer c.Close()
+
+	c.WriteMessage(websocket.TextMessage, []byte(`{"type": "hello"}`))
+	c.WriteMessage(websocket.TextMessage, []byte(`{"type": "goodbye"}`))
+
+	for {
+		_, _, err := c.ReadMessage()
+		if err != nil {
+			return
+		}
+	}
+}
+
+// SocketModeEnvelopeID is the envelope ID of the payloads that socketModeServer and socketModeInteractiveServer send.
+const SocketModeEnvelopeID = "57d6a792-4d35-4d0b-b6aa-3361493e1caf"
+
+// socketModeServer sends hello and events_api payloads, and then waits for the acknowledgement.
+// When the expected acknowledgement is given, this sends disconnect payload.
+func socketModeServer(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{}
+	c, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		panic(fmt.Errorf("failed to upgrade protocol: %s", err.Error()))
+	}
+	defer c.Close()
+
+	hello := `{"type": "hello", "num_connections": 1, "connection_info": {"app_id": "A0FFV41KK"}}`
+	c.WriteMessage(websocket.TextMessage, []byte(hello))
+
+	eventsAPI := fmt.Sprintf(`{"type": "events_api", "envelope_id": "%s", "accepts_response_payload": false, "payload": {
+		"token": "z26uFbvR1xHJEdHE1OQiO6t8",
+		"team_id": "T061EG9RZ",
+		"api_app_id": "A0FFV41KK",
+		"event": {"type": "app_mention", "user": "U061F7AUR", "text": "<@U0LAN0Z89> hi", "ts": "1515449522.000016", "channel": "C0LAN2Q65", "event_ts": "1515449522000016"},
+		"type": "event_callback",
+		"event_id": "Ev0LAN670R",
+		"event_time": 1515449522
+	}}`, SocketModeEnvelopeID)
+	c.WriteMessage(websocket.TextMessage, []byte(eventsAPI))
+
+	for {
+		_, message, err := c.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		ack := &struct {
+			EnvelopeID string `json:"envelope_id"`
+		}{}
+		err = json.Unmarshal(message, ack)
+		if err != nil || ack.EnvelopeID != SocketModeEnvelopeID {
+			continue
+		}
+
+		disconnect := `{"type": "disconnect", "reason": "warning", "debug_info": {"host": "wss-111.slack.com"}}`
+		c.WriteMessage(websocket.TextMessage, []byte(disconnect))
+	}
+}
+
+// socketModeInteractiveServer sends an interactive envelope and keeps the connection open until the client closes it.
+func socketModeInteractiveServer(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{}
+	c, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		panic(fmt.Errorf("failed to upgrade protocol: %s", err.Error()))
+	}
+	defer c.Close()
+
+	hello := `{"type": "hello", "num_connections": 1, "connection_info": {"app_id": "A0FFV41KK"}}`
+	c.WriteMessage(websocket.TextMessage, []byte(hello))
+
+	interactive := fmt.Sprintf(`{"type": "interactive", "envelope_id": "%s", "accepts_response_payload": true, "payload": {
+		"type": "block_actions",
+		"team": {"id": "T061EG9RZ", "domain": "example"}
+	}}`, SocketModeEnvelopeID)
+	c.WriteMessage(websocket.TextMessage, []byte(interactive))
+
+	for {
+		_, _, err := c.ReadMessage()
+		if err != nil {
+			return
+		}
+	}
+}
+
 func RunWithWebSocket(fnc func(addr net.Addr)) {
 	// Setup server
 	mux := http.NewServeMux()
 	mux.HandleFunc("/echo", echoServer)
 	mux.HandleFunc("/ping", pingServer)
+	mux.HandleFunc("/pong", pongServer)
+	mux.HandleFunc("/reply", replyServer)
+	mux.HandleFunc("/goodbye", goodbyeServer)
+	mux.HandleFunc("/socket_mode", socketModeServer)
+	mux.HandleFunc("/socket_mode_interactive", socketModeInteractiveServer)
 	server := httptest.NewServer(mux)
 
 	// Close after test
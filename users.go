@@ -0,0 +1,116 @@
+package golack
+
+import (
+	"context"
+	"github.com/oklahomer/golack/v2/event"
+	"github.com/oklahomer/golack/v2/webapi"
+	"net/url"
+	"time"
+)
+
+// GetUserInfo retrieves information about a user.
+//
+// See https://api.slack.com/methods/users.info for official document.
+func (g *Golack) GetUserInfo(ctx context.Context, req *webapi.UsersInfoRequest) (*webapi.UserResponse, error) {
+	response := &webapi.UserResponse{}
+	err := g.get(ctx, "users.info", req.ToURLValues(), response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// ListUsers fetches a page of users in the workspace.
+// To iterate over all users, use webapi.Client.IterateUsers.
+//
+// See https://api.slack.com/methods/users.list for official document.
+func (g *Golack) ListUsers(ctx context.Context, req *webapi.UsersListRequest) (*webapi.UsersList, error) {
+	response := &webapi.UsersList{}
+	err := g.get(ctx, "users.list", req.ToURLValues(), response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// LookupUserByEmail finds a user with the given email address.
+//
+// See https://api.slack.com/methods/users.lookupByEmail for official document.
+func (g *Golack) LookupUserByEmail(ctx context.Context, email string) (*webapi.UserResponse, error) {
+	response := &webapi.UserResponse{}
+	err := g.get(ctx, "users.lookupByEmail", url.Values{"email": []string{email}}, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetUserPresence retrieves the presence of the given user.
+//
+// See https://api.slack.com/methods/users.getPresence for official document.
+func (g *Golack) GetUserPresence(ctx context.Context, userID event.UserID) (*webapi.Presence, error) {
+	response := &webapi.Presence{}
+	err := g.get(ctx, "users.getPresence", url.Values{"user": []string{userID.String()}}, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// SetUserPresence manually sets the presence of the authenticated user.
+//
+// See https://api.slack.com/methods/users.setPresence for official document.
+func (g *Golack) SetUserPresence(ctx context.Context, presence webapi.PresenceType) (*webapi.APIResponse, error) {
+	response := &webapi.APIResponse{}
+	err := g.post(ctx, "users.setPresence", &webapi.SetPresence{Presence: presence}, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetUserProfile retrieves the profile of the given user.
+// The authenticated user's profile is returned when an empty user ID is given.
+//
+// See https://api.slack.com/methods/users.profile.get for official document.
+func (g *Golack) GetUserProfile(ctx context.Context, userID event.UserID) (*webapi.UserProfileResponse, error) {
+	values := url.Values{}
+	if userID != "" {
+		values.Set("user", userID.String())
+	}
+	response := &webapi.UserProfileResponse{}
+	err := g.get(ctx, "users.profile.get", values, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// SetUserProfile updates the profile fields given by webapi.SetUserProfile.
+//
+// See https://api.slack.com/methods/users.profile.set for official document.
+func (g *Golack) SetUserProfile(ctx context.Context, profile *webapi.SetUserProfile) (*webapi.UserProfileResponse, error) {
+	response := &webapi.UserProfileResponse{}
+	err := g.post(ctx, "users.profile.set", profile, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// SetUserStatus sets the custom status of the given user with the given text and emoji such as ":pager:".
+// The status is cleared at the given expiration. Give zero time.Time for a status that does not expire.
+// Give an empty user ID to update the authenticated user. Updating other users requires an admin token.
+//
+// See https://api.slack.com/docs/presence-and-status#custom_status for official document.
+func (g *Golack) SetUserStatus(ctx context.Context, userID event.UserID, text string, emoji string, expiration time.Time) (*webapi.UserProfileResponse, error) {
+	return g.SetUserProfile(ctx, webapi.NewSetUserStatus(text, emoji, expiration).WithUserID(userID))
+}
+
+// ClearUserStatus clears the custom status of the given user.
+// Give an empty user ID to update the authenticated user. Updating other users requires an admin token.
+//
+// See https://api.slack.com/docs/presence-and-status#custom_status for official document.
+func (g *Golack) ClearUserStatus(ctx context.Context, userID event.UserID) (*webapi.UserProfileResponse, error) {
+	return g.SetUserProfile(ctx, webapi.NewClearUserStatus().WithUserID(userID))
+}
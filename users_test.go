@@ -0,0 +1,516 @@
+package golack
+
+import (
+	"context"
+	"errors"
+	"github.com/oklahomer/golack/v2/webapi"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGolack_GetUserInfo(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, _ string, _ url.Values, response interface{}) error {
+				resp := response.(*webapi.UserResponse)
+				resp.OK = false
+				resp.Error = "user_not_found"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.GetUserInfo(context.TODO(), webapi.NewUsersInfoRequest("U123"))
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "users.info" || apiErr.Code != "user_not_found" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, slackMethod string, queryParams url.Values, response interface{}) error {
+				if slackMethod != "users.info" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if queryParams.Encode() != "user=U123" {
+					t.Errorf("Unexpected query parameters are given: %s.", queryParams.Encode())
+				}
+
+				response.(*webapi.UserResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.GetUserInfo(context.TODO(), webapi.NewUsersInfoRequest("U123"))
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_ListUsers(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, _ string, _ url.Values, response interface{}) error {
+				resp := response.(*webapi.UsersList)
+				resp.OK = false
+				resp.Error = "invalid_cursor"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.ListUsers(context.TODO(), webapi.NewUsersListRequest().WithLimit(10))
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "users.list" || apiErr.Code != "invalid_cursor" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, slackMethod string, queryParams url.Values, response interface{}) error {
+				if slackMethod != "users.list" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if queryParams.Encode() != "limit=10" {
+					t.Errorf("Unexpected query parameters are given: %s.", queryParams.Encode())
+				}
+
+				response.(*webapi.UsersList).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.ListUsers(context.TODO(), webapi.NewUsersListRequest().WithLimit(10))
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_LookupUserByEmail(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, _ string, _ url.Values, response interface{}) error {
+				resp := response.(*webapi.UserResponse)
+				resp.OK = false
+				resp.Error = "users_not_found"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.LookupUserByEmail(context.TODO(), "oncall@example.com")
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "users.lookupByEmail" || apiErr.Code != "users_not_found" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, slackMethod string, queryParams url.Values, response interface{}) error {
+				if slackMethod != "users.lookupByEmail" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if queryParams.Encode() != "email=oncall%40example.com" {
+					t.Errorf("Unexpected query parameters are given: %s.", queryParams.Encode())
+				}
+
+				response.(*webapi.UserResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.LookupUserByEmail(context.TODO(), "oncall@example.com")
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_GetUserPresence(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, _ string, _ url.Values, response interface{}) error {
+				resp := response.(*webapi.Presence)
+				resp.OK = false
+				resp.Error = "user_not_found"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.GetUserPresence(context.TODO(), "U123")
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "users.getPresence" || apiErr.Code != "user_not_found" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, slackMethod string, queryParams url.Values, response interface{}) error {
+				if slackMethod != "users.getPresence" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if queryParams.Encode() != "user=U123" {
+					t.Errorf("Unexpected query parameters are given: %s.", queryParams.Encode())
+				}
+
+				response.(*webapi.Presence).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.GetUserPresence(context.TODO(), "U123")
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_SetUserPresence(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.APIResponse)
+				resp.OK = false
+				resp.Error = "invalid_presence"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.SetUserPresence(context.TODO(), webapi.PresenceAway)
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "users.setPresence" || apiErr.Code != "invalid_presence" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "users.setPresence" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				expected := &webapi.SetPresence{Presence: webapi.PresenceAway}
+				if !reflect.DeepEqual(payload, expected) {
+					t.Errorf("Unexpected payload is given: %#v.", payload)
+				}
+
+				response.(*webapi.APIResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.SetUserPresence(context.TODO(), webapi.PresenceAway)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_GetUserProfile(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, _ string, _ url.Values, response interface{}) error {
+				resp := response.(*webapi.UserProfileResponse)
+				resp.OK = false
+				resp.Error = "user_not_found"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.GetUserProfile(context.TODO(), "U123")
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "users.profile.get" || apiErr.Code != "user_not_found" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			GetFunc: func(_ context.Context, slackMethod string, queryParams url.Values, response interface{}) error {
+				if slackMethod != "users.profile.get" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if queryParams.Encode() != "user=U123" {
+					t.Errorf("Unexpected query parameters are given: %s.", queryParams.Encode())
+				}
+
+				response.(*webapi.UserProfileResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.GetUserProfile(context.TODO(), "U123")
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_SetUserProfile(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		profile := webapi.NewSetUserProfile().WithField("title", "SRE")
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.UserProfileResponse)
+				resp.OK = false
+				resp.Error = "invalid_profile"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.SetUserProfile(context.TODO(), profile)
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "users.profile.set" || apiErr.Code != "invalid_profile" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		profile := webapi.NewSetUserProfile().WithField("title", "SRE")
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "users.profile.set" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if payload != profile {
+					t.Errorf("Given payload is not passed: %#v.", payload)
+				}
+
+				response.(*webapi.UserProfileResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.SetUserProfile(context.TODO(), profile)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_SetUserStatus(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.UserProfileResponse)
+				resp.OK = false
+				resp.Error = "cannot_update_admin_user"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.SetUserStatus(context.TODO(), "U123", "On call", ":pager:", time.Unix(1532627506, 0))
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "users.profile.set" || apiErr.Code != "cannot_update_admin_user" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "users.profile.set" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				expected := &webapi.SetUserProfile{
+					UserID: "U123",
+					Profile: map[string]interface{}{
+						"status_text":       "On call",
+						"status_emoji":      ":pager:",
+						"status_expiration": int64(1532627506),
+					},
+				}
+				if !reflect.DeepEqual(payload, expected) {
+					t.Errorf("Unexpected payload is given: %#v.", payload)
+				}
+
+				response.(*webapi.UserProfileResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.SetUserStatus(context.TODO(), "U123", "On call", ":pager:", time.Unix(1532627506, 0))
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_ClearUserStatus(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.UserProfileResponse)
+				resp.OK = false
+				resp.Error = "cannot_update_admin_user"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.ClearUserStatus(context.TODO(), "U123")
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "users.profile.set" || apiErr.Code != "cannot_update_admin_user" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "users.profile.set" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				expected := &webapi.SetUserProfile{
+					UserID: "U123",
+					Profile: map[string]interface{}{
+						"status_text":       "",
+						"status_emoji":      "",
+						"status_expiration": int64(0),
+					},
+				}
+				if !reflect.DeepEqual(payload, expected) {
+					t.Errorf("Unexpected payload is given: %#v.", payload)
+				}
+
+				response.(*webapi.UserProfileResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.ClearUserStatus(context.TODO(), "U123")
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package golack
+
+import (
+	"context"
+	"github.com/oklahomer/golack/v2/webapi"
+)
+
+// OpenView opens a modal with the trigger_id given by an interaction, and returns the opened view.
+//
+// See https://api.slack.com/methods/views.open for official document.
+func (g *Golack) OpenView(ctx context.Context, view *webapi.OpenView) (*webapi.ViewResponse, error) {
+	return g.postView(ctx, "views.open", view)
+}
+
+// PushView pushes a modal onto the stack of the currently opened modal, and returns the pushed view.
+//
+// See https://api.slack.com/methods/views.push for official document.
+func (g *Golack) PushView(ctx context.Context, view *webapi.PushView) (*webapi.ViewResponse, error) {
+	return g.postView(ctx, "views.push", view)
+}
+
+// UpdateView updates an existing view, and returns the updated view.
+// When the hash is set and the view is already updated by another request, *webapi.APIError with hash_conflict code is returned.
+//
+// See https://api.slack.com/methods/views.update for official document.
+func (g *Golack) UpdateView(ctx context.Context, view *webapi.UpdateView) (*webapi.ViewResponse, error) {
+	return g.postView(ctx, "views.update", view)
+}
+
+// PublishView publishes a Home tab for a user, and returns the published view.
+// When the hash is set and the Home tab is already updated by another request, *webapi.APIError with hash_conflict code is returned.
+//
+// See https://api.slack.com/methods/views.publish for official document.
+func (g *Golack) PublishView(ctx context.Context, view *webapi.PublishView) (*webapi.ViewResponse, error) {
+	return g.postView(ctx, "views.publish", view)
+}
+
+func (g *Golack) postView(ctx context.Context, slackMethod string, payload interface{}) (*webapi.ViewResponse, error) {
+	response := &webapi.ViewResponse{}
+	err := g.post(ctx, slackMethod, payload, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
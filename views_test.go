@@ -0,0 +1,239 @@
+package golack
+
+import (
+	"context"
+	"errors"
+	"github.com/oklahomer/golack/v2/event"
+	"github.com/oklahomer/golack/v2/webapi"
+	"testing"
+)
+
+func TestGolack_OpenView(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		modal := webapi.NewModalView(event.NewPlainTextCompositionObject("Title"), []event.Block{event.NewDividerBlock()})
+		view := webapi.NewOpenView("trigger", modal)
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.ViewResponse)
+				resp.OK = false
+				resp.Error = "expired_trigger_id"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.OpenView(context.TODO(), view)
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "views.open" || apiErr.Code != "expired_trigger_id" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		modal := webapi.NewModalView(event.NewPlainTextCompositionObject("Title"), []event.Block{event.NewDividerBlock()})
+		view := webapi.NewOpenView("trigger", modal)
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "views.open" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if payload != view {
+					t.Errorf("Given payload is not passed: %#v.", payload)
+				}
+
+				response.(*webapi.ViewResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.OpenView(context.TODO(), view)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_PushView(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		modal := webapi.NewModalView(event.NewPlainTextCompositionObject("Title"), []event.Block{event.NewDividerBlock()})
+		view := webapi.NewPushView("trigger", modal)
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.ViewResponse)
+				resp.OK = false
+				resp.Error = "push_limit_reached"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.PushView(context.TODO(), view)
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "views.push" || apiErr.Code != "push_limit_reached" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		modal := webapi.NewModalView(event.NewPlainTextCompositionObject("Title"), []event.Block{event.NewDividerBlock()})
+		view := webapi.NewPushView("trigger", modal)
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "views.push" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if payload != view {
+					t.Errorf("Given payload is not passed: %#v.", payload)
+				}
+
+				response.(*webapi.ViewResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.PushView(context.TODO(), view)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_UpdateView(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		modal := webapi.NewModalView(event.NewPlainTextCompositionObject("Title"), []event.Block{event.NewDividerBlock()})
+		view := webapi.NewUpdateView("V123", modal).WithHash("hash")
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.ViewResponse)
+				resp.OK = false
+				resp.Error = "hash_conflict"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.UpdateView(context.TODO(), view)
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "views.update" || apiErr.Code != "hash_conflict" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		modal := webapi.NewModalView(event.NewPlainTextCompositionObject("Title"), []event.Block{event.NewDividerBlock()})
+		view := webapi.NewUpdateView("V123", modal).WithHash("hash")
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "views.update" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if payload != view {
+					t.Errorf("Given payload is not passed: %#v.", payload)
+				}
+
+				response.(*webapi.ViewResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.UpdateView(context.TODO(), view)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
+
+func TestGolack_PublishView(t *testing.T) {
+	t.Run("Web API returns error response", func(t *testing.T) {
+		view := webapi.NewPublishView("U123", webapi.NewHomeView([]event.Block{event.NewDividerBlock()}))
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, _ string, _ interface{}, response interface{}) error {
+				resp := response.(*webapi.ViewResponse)
+				resp.OK = false
+				resp.Error = "hash_conflict"
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		_, err := g.PublishView(context.TODO(), view)
+
+		var apiErr *webapi.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+		if apiErr.Method != "views.publish" || apiErr.Code != "hash_conflict" {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		view := webapi.NewPublishView("U123", webapi.NewHomeView([]event.Block{event.NewDividerBlock()}))
+		webClient := &DummyWebClient{
+			PostFunc: func(_ context.Context, slackMethod string, payload interface{}, response interface{}) error {
+				if slackMethod != "views.publish" {
+					t.Errorf("Unexpected method is called: %s.", slackMethod)
+				}
+				if payload != view {
+					t.Errorf("Given payload is not passed: %#v.", payload)
+				}
+
+				response.(*webapi.ViewResponse).OK = true
+				return nil
+			},
+		}
+		g := &Golack{
+			WebClient: webClient,
+		}
+
+		response, err := g.PublishView(context.TODO(), view)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Errorf("Unexpected response is returned: %#v.", response)
+		}
+	})
+}
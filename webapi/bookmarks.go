@@ -0,0 +1,122 @@
+package webapi
+
+import (
+	"github.com/oklahomer/golack/v2/event"
+)
+
+// Bookmark represents a bookmark in a channel.
+// See https://api.slack.com/methods/bookmarks.add
+type Bookmark struct {
+	ID                  string           `json:"id"`
+	ChannelID           event.ChannelID  `json:"channel_id"`
+	Title               string           `json:"title"`
+	Link                string           `json:"link"`
+	Emoji               string           `json:"emoji"`
+	IconURL             string           `json:"icon_url"`
+	Type                string           `json:"type"`
+	EntityID            string           `json:"entity_id"`
+	DateCreated         *event.TimeStamp `json:"date_created"`
+	DateUpdated         *event.TimeStamp `json:"date_updated"`
+	Rank                string           `json:"rank"`
+	LastUpdatedByUserID event.UserID     `json:"last_updated_by_user_id"`
+	LastUpdatedByTeamID event.TeamID     `json:"last_updated_by_team_id"`
+	ShortcutID          string           `json:"shortcut_id"`
+	AppID               event.AppID      `json:"app_id"`
+}
+
+// BookmarkResponse is a response of bookmarks.add and bookmarks.edit methods.
+type BookmarkResponse struct {
+	APIResponse
+	Bookmark *Bookmark `json:"bookmark"`
+}
+
+// BookmarksResponse is a response of bookmarks.list method.
+// See https://api.slack.com/methods/bookmarks.list
+type BookmarksResponse struct {
+	APIResponse
+	Bookmarks []*Bookmark `json:"bookmarks"`
+}
+
+// AddBookmark is a payload to be sent with bookmarks.add method.
+// See https://api.slack.com/methods/bookmarks.add
+type AddBookmark struct {
+	ChannelID event.ChannelID `json:"channel_id"`
+	Title     string          `json:"title"`
+	Type      string          `json:"type"`
+	Link      string          `json:"link,omitempty"`
+	Emoji     string          `json:"emoji,omitempty"`
+	EntityID  string          `json:"entity_id,omitempty"`
+	ParentID  string          `json:"parent_id,omitempty"`
+}
+
+// WithEmoji sets the emoji tag to apply to the link.
+func (bookmark *AddBookmark) WithEmoji(emoji string) *AddBookmark {
+	bookmark.Emoji = emoji
+	return bookmark
+}
+
+// WithEntityID sets the ID of the entity being bookmarked.
+func (bookmark *AddBookmark) WithEntityID(entityID string) *AddBookmark {
+	bookmark.EntityID = entityID
+	return bookmark
+}
+
+// WithParentID sets the ID of the parent bookmark.
+func (bookmark *AddBookmark) WithParentID(parentID string) *AddBookmark {
+	bookmark.ParentID = parentID
+	return bookmark
+}
+
+// NewAddBookmark creates AddBookmark instance to bookmark the given link in the given channel.
+func NewAddBookmark(channelID event.ChannelID, title string, link string) *AddBookmark {
+	return &AddBookmark{
+		ChannelID: channelID,
+		Title:     title,
+		Type:      "link",
+		Link:      link,
+	}
+}
+
+// EditBookmark is a payload to be sent with bookmarks.edit method.
+// Only the fields that are set are updated.
+// See https://api.slack.com/methods/bookmarks.edit
+type EditBookmark struct {
+	BookmarkID string          `json:"bookmark_id"`
+	ChannelID  event.ChannelID `json:"channel_id"`
+	Title      string          `json:"title,omitempty"`
+	Link       string          `json:"link,omitempty"`
+	Emoji      string          `json:"emoji,omitempty"`
+}
+
+// WithTitle sets the new title of the bookmark.
+func (bookmark *EditBookmark) WithTitle(title string) *EditBookmark {
+	bookmark.Title = title
+	return bookmark
+}
+
+// WithLink sets the new link of the bookmark.
+func (bookmark *EditBookmark) WithLink(link string) *EditBookmark {
+	bookmark.Link = link
+	return bookmark
+}
+
+// WithEmoji sets the new emoji tag of the bookmark.
+func (bookmark *EditBookmark) WithEmoji(emoji string) *EditBookmark {
+	bookmark.Emoji = emoji
+	return bookmark
+}
+
+// NewEditBookmark creates EditBookmark instance to edit the given bookmark in the given channel.
+func NewEditBookmark(channelID event.ChannelID, bookmarkID string) *EditBookmark {
+	return &EditBookmark{
+		BookmarkID: bookmarkID,
+		ChannelID:  channelID,
+	}
+}
+
+// RemoveBookmark is a payload to be sent with bookmarks.remove method.
+// See https://api.slack.com/methods/bookmarks.remove
+type RemoveBookmark struct {
+	BookmarkID string          `json:"bookmark_id"`
+	ChannelID  event.ChannelID `json:"channel_id"`
+}
@@ -0,0 +1,35 @@
+package webapi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNewAddBookmark(t *testing.T) {
+	bookmark := NewAddBookmark("C123", "Runbook", "https://example.com/runbook").WithEmoji(":book:")
+
+	b, err := json.Marshal(bookmark)
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+
+	expected := `{"channel_id":"C123","title":"Runbook","type":"link","link":"https://example.com/runbook","emoji":":book:"}`
+	if string(b) != expected {
+		t.Errorf("Unexpected payload is built: %s.", string(b))
+	}
+}
+
+func TestNewEditBookmark(t *testing.T) {
+	bookmark := NewEditBookmark("C123", "Bk123").WithLink("https://example.com/new")
+
+	b, err := json.Marshal(bookmark)
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+
+	// Unset fields must not be sent so they remain untouched
+	expected := `{"bookmark_id":"Bk123","channel_id":"C123","link":"https://example.com/new"}`
+	if string(b) != expected {
+		t.Errorf("Unexpected payload is built: %s.", string(b))
+	}
+}
@@ -0,0 +1,444 @@
+package webapi
+
+import (
+	"github.com/oklahomer/golack/v2/event"
+	"net/url"
+	"time"
+)
+
+// ChatResponse is a response of chat.* methods that return the posted, updated or deleted message's timestamp.
+// Use the returned ChannelID and TimeStamp to update or delete the message later.
+type ChatResponse struct {
+	APIResponse
+	ChannelID event.ChannelID      `json:"channel"`
+	TimeStamp *event.TimeStamp     `json:"ts"`
+	Text      string               `json:"text"`
+	Message   *ConversationMessage `json:"message"`
+}
+
+// UpdateMessage is a payload to be sent with chat.update method.
+// See https://api.slack.com/methods/chat.update
+type UpdateMessage struct {
+	ChannelID      event.ChannelID      `json:"channel"`
+	TimeStamp      string               `json:"ts"`
+	Text           string               `json:"text"`
+	AsUser         bool                 `json:"as_user,omitempty"`
+	Attachments    []*MessageAttachment `json:"attachments,omitempty"`
+	Blocks         []event.Block        `json:"blocks,omitempty"`
+	LinkNames      int                  `json:"link_names,omitempty"`
+	Parse          ParseMode            `json:"parse,omitempty"`
+	ReplyBroadcast bool                 `json:"reply_broadcast,omitempty"`
+}
+
+// WithAsUser sets optional boolean value so the message is updated as the authenticated user.
+// See https://api.slack.com/methods/chat.update
+func (message *UpdateMessage) WithAsUser(flg bool) *UpdateMessage {
+	message.AsUser = flg
+	return message
+}
+
+// WithAttachments sets/overrides attachments parameter for current UpdateMessage.
+// See https://api.slack.com/docs/message-attachments
+func (message *UpdateMessage) WithAttachments(attachments []*MessageAttachment) *UpdateMessage {
+	message.Attachments = attachments
+	return message
+}
+
+// WithBlocks sets/overrides blocks parameter for current UpdateMessage.
+// See https://api.slack.com/reference/block-kit/blocks
+func (message *UpdateMessage) WithBlocks(blocks []event.Block) *UpdateMessage {
+	message.Blocks = blocks
+	return message
+}
+
+// WithLinkNames sets link_names parameter for current UpdateMessage.
+// See https://api.slack.com/methods/chat.update
+func (message *UpdateMessage) WithLinkNames(linkNames int) *UpdateMessage {
+	message.LinkNames = linkNames
+	return message
+}
+
+// WithParse sets parse parameter for current UpdateMessage.
+// See https://api.slack.com/docs/message-formatting#parsing_modes
+func (message *UpdateMessage) WithParse(parse ParseMode) *UpdateMessage {
+	message.Parse = parse
+	return message
+}
+
+// WithReplyBroadcast sets optional boolean value so the updated reply is also broadcast to the channel.
+// See https://api.slack.com/methods/chat.update
+func (message *UpdateMessage) WithReplyBroadcast(flg bool) *UpdateMessage {
+	message.ReplyBroadcast = flg
+	return message
+}
+
+// NewUpdateMessage creates UpdateMessage instance to replace the text of the message with the given channel and timestamp.
+func NewUpdateMessage(channelID event.ChannelID, ts string, text string) *UpdateMessage {
+	return &UpdateMessage{
+		ChannelID: channelID,
+		TimeStamp: ts,
+		Text:      text,
+	}
+}
+
+// DeleteMessage is a payload to be sent with chat.delete method.
+// See https://api.slack.com/methods/chat.delete
+type DeleteMessage struct {
+	ChannelID event.ChannelID `json:"channel"`
+	TimeStamp string          `json:"ts"`
+	AsUser    bool            `json:"as_user,omitempty"`
+}
+
+// WithAsUser sets optional boolean value so the message is deleted as the authenticated user.
+// See https://api.slack.com/methods/chat.delete
+func (message *DeleteMessage) WithAsUser(flg bool) *DeleteMessage {
+	message.AsUser = flg
+	return message
+}
+
+// NewDeleteMessage creates DeleteMessage instance to delete the message with the given channel and timestamp.
+func NewDeleteMessage(channelID event.ChannelID, ts string) *DeleteMessage {
+	return &DeleteMessage{
+		ChannelID: channelID,
+		TimeStamp: ts,
+	}
+}
+
+// PostEphemeralResponse is a response of chat.postEphemeral method.
+// See https://api.slack.com/methods/chat.postEphemeral
+type PostEphemeralResponse struct {
+	APIResponse
+	MessageTimeStamp *event.TimeStamp `json:"message_ts"`
+}
+
+// PostEphemeral is a payload to be sent with chat.postEphemeral method.
+// The message is only visible to the given user in the given channel.
+// See https://api.slack.com/methods/chat.postEphemeral
+type PostEphemeral struct {
+	ChannelID       event.ChannelID      `json:"channel"`
+	UserID          event.UserID         `json:"user"`
+	Text            string               `json:"text"`
+	AsUser          bool                 `json:"as_user,omitempty"`
+	Attachments     []*MessageAttachment `json:"attachments,omitempty"`
+	Blocks          []event.Block        `json:"blocks,omitempty"`
+	IconEmoji       string               `json:"icon_emoji,omitempty"`
+	IconURL         string               `json:"icon_url,omitempty"`
+	LinkNames       int                  `json:"link_names,omitempty"`
+	Parse           ParseMode            `json:"parse,omitempty"`
+	ThreadTimeStamp string               `json:"thread_ts,omitempty"`
+	UserName        string               `json:"username,omitempty"`
+}
+
+// WithAsUser sets optional boolean value so the outgoing message is sent as a user.
+// See https://api.slack.com/methods/chat.postEphemeral
+func (message *PostEphemeral) WithAsUser(flg bool) *PostEphemeral {
+	message.AsUser = flg
+	return message
+}
+
+// WithAttachments sets/overrides attachments parameter for current PostEphemeral.
+// See https://api.slack.com/docs/message-attachments
+func (message *PostEphemeral) WithAttachments(attachments []*MessageAttachment) *PostEphemeral {
+	message.Attachments = attachments
+	return message
+}
+
+// WithBlocks sets/overrides blocks parameter for current PostEphemeral.
+// See https://api.slack.com/reference/block-kit/blocks
+func (message *PostEphemeral) WithBlocks(blocks []event.Block) *PostEphemeral {
+	message.Blocks = blocks
+	return message
+}
+
+// WithIconEmoji sets emoji to be used as an icon.
+// See https://api.slack.com/methods/chat.postEphemeral
+func (message *PostEphemeral) WithIconEmoji(iconEmoji string) *PostEphemeral {
+	message.IconEmoji = iconEmoji
+	return message
+}
+
+// WithIconURL sets the URL of an image to be used as an icon.
+// See https://api.slack.com/methods/chat.postEphemeral
+func (message *PostEphemeral) WithIconURL(iconURL string) *PostEphemeral {
+	message.IconURL = iconURL
+	return message
+}
+
+// WithLinkNames sets link_names parameter for current PostEphemeral.
+// See https://api.slack.com/methods/chat.postEphemeral
+func (message *PostEphemeral) WithLinkNames(linkNames int) *PostEphemeral {
+	message.LinkNames = linkNames
+	return message
+}
+
+// WithParse sets parse parameter for current PostEphemeral.
+// See https://api.slack.com/docs/message-formatting#parsing_modes
+func (message *PostEphemeral) WithParse(parse ParseMode) *PostEphemeral {
+	message.Parse = parse
+	return message
+}
+
+// WithThreadTimeStamp sets the timestamp of the parent message so the ephemeral message is shown in the thread.
+// See https://api.slack.com/methods/chat.postEphemeral
+func (message *PostEphemeral) WithThreadTimeStamp(ts string) *PostEphemeral {
+	message.ThreadTimeStamp = ts
+	return message
+}
+
+// WithUserName sets the name of the bot.
+// See https://api.slack.com/methods/chat.postEphemeral
+func (message *PostEphemeral) WithUserName(name string) *PostEphemeral {
+	message.UserName = name
+	return message
+}
+
+// NewPostEphemeral creates PostEphemeral instance with given channel, user and text settings.
+// Like NewPostMessage, this sets link_names=1 and parse=full by default.
+func NewPostEphemeral(channelID event.ChannelID, userID event.UserID, text string) *PostEphemeral {
+	return &PostEphemeral{
+		ChannelID: channelID,
+		UserID:    userID,
+		Text:      text,
+		Parse:     ParseModeFull,
+		LinkNames: 1,
+	}
+}
+
+// ScheduleMessageResponse is a response of chat.scheduleMessage method.
+// Use ScheduledMessageID to delete the scheduled message with chat.deleteScheduledMessage method.
+// See https://api.slack.com/methods/chat.scheduleMessage
+type ScheduleMessageResponse struct {
+	APIResponse
+	ChannelID          event.ChannelID      `json:"channel"`
+	ScheduledMessageID string               `json:"scheduled_message_id"`
+	PostAt             *event.TimeStamp     `json:"post_at"`
+	Message            *ConversationMessage `json:"message"`
+}
+
+// ScheduleMessage is a payload to be sent with chat.scheduleMessage method.
+// See https://api.slack.com/methods/chat.scheduleMessage
+type ScheduleMessage struct {
+	ChannelID       event.ChannelID      `json:"channel"`
+	PostAt          int64                `json:"post_at"`
+	Text            string               `json:"text"`
+	AsUser          bool                 `json:"as_user,omitempty"`
+	Attachments     []*MessageAttachment `json:"attachments,omitempty"`
+	Blocks          []event.Block        `json:"blocks,omitempty"`
+	LinkNames       int                  `json:"link_names,omitempty"`
+	Parse           ParseMode            `json:"parse,omitempty"`
+	ReplyBroadcast  bool                 `json:"reply_broadcast,omitempty"`
+	ThreadTimeStamp string               `json:"thread_ts,omitempty"`
+	UnfurlLinks     bool                 `json:"unfurl_links,omitempty"`
+	UnfurlMedia     bool                 `json:"unfurl_media,omitempty"`
+}
+
+// WithAsUser sets optional boolean value so the outgoing message is sent as a user.
+// See https://api.slack.com/methods/chat.scheduleMessage
+func (message *ScheduleMessage) WithAsUser(flg bool) *ScheduleMessage {
+	message.AsUser = flg
+	return message
+}
+
+// WithAttachments sets/overrides attachments parameter for current ScheduleMessage.
+// See https://api.slack.com/docs/message-attachments
+func (message *ScheduleMessage) WithAttachments(attachments []*MessageAttachment) *ScheduleMessage {
+	message.Attachments = attachments
+	return message
+}
+
+// WithBlocks sets/overrides blocks parameter for current ScheduleMessage.
+// See https://api.slack.com/reference/block-kit/blocks
+func (message *ScheduleMessage) WithBlocks(blocks []event.Block) *ScheduleMessage {
+	message.Blocks = blocks
+	return message
+}
+
+// WithLinkNames sets link_names parameter for current ScheduleMessage.
+// See https://api.slack.com/methods/chat.scheduleMessage
+func (message *ScheduleMessage) WithLinkNames(linkNames int) *ScheduleMessage {
+	message.LinkNames = linkNames
+	return message
+}
+
+// WithParse sets parse parameter for current ScheduleMessage.
+// See https://api.slack.com/docs/message-formatting#parsing_modes
+func (message *ScheduleMessage) WithParse(parse ParseMode) *ScheduleMessage {
+	message.Parse = parse
+	return message
+}
+
+// WithReplyBroadcast sets optional boolean value so the reply is also broadcast to the channel.
+// See https://api.slack.com/methods/chat.scheduleMessage
+func (message *ScheduleMessage) WithReplyBroadcast(flg bool) *ScheduleMessage {
+	message.ReplyBroadcast = flg
+	return message
+}
+
+// WithThreadTimeStamp sets the timestamp of the parent message to post the message as a reply.
+// See https://api.slack.com/methods/chat.scheduleMessage
+func (message *ScheduleMessage) WithThreadTimeStamp(ts string) *ScheduleMessage {
+	message.ThreadTimeStamp = ts
+	return message
+}
+
+// WithUnfurlLinks sets optional boolean value to enable unfurling of primarily text-based content.
+// See https://api.slack.com/methods/chat.scheduleMessage
+func (message *ScheduleMessage) WithUnfurlLinks(unfurl bool) *ScheduleMessage {
+	message.UnfurlLinks = unfurl
+	return message
+}
+
+// WithUnfurlMedia sets optional boolean value to enable unfurling of media content.
+// See https://api.slack.com/methods/chat.scheduleMessage
+func (message *ScheduleMessage) WithUnfurlMedia(unfurl bool) *ScheduleMessage {
+	message.UnfurlMedia = unfurl
+	return message
+}
+
+// NewScheduleMessage creates ScheduleMessage instance to post the given text to the given channel at the given time.
+// Like NewPostMessage, this sets link_names=1, unfurl_links=true and so on by default.
+func NewScheduleMessage(channelID event.ChannelID, postAt time.Time, text string) *ScheduleMessage {
+	return &ScheduleMessage{
+		ChannelID:   channelID,
+		PostAt:      postAt.Unix(),
+		Text:        text,
+		Parse:       ParseModeFull,
+		LinkNames:   1,
+		UnfurlLinks: true,
+		UnfurlMedia: true,
+	}
+}
+
+// DeleteScheduledMessage is a payload to be sent with chat.deleteScheduledMessage method.
+// See https://api.slack.com/methods/chat.deleteScheduledMessage
+type DeleteScheduledMessage struct {
+	ChannelID          event.ChannelID `json:"channel"`
+	ScheduledMessageID string          `json:"scheduled_message_id"`
+	AsUser             bool            `json:"as_user,omitempty"`
+}
+
+// WithAsUser sets optional boolean value so the scheduled message is deleted as the authenticated user.
+// See https://api.slack.com/methods/chat.deleteScheduledMessage
+func (message *DeleteScheduledMessage) WithAsUser(flg bool) *DeleteScheduledMessage {
+	message.AsUser = flg
+	return message
+}
+
+// NewDeleteScheduledMessage creates DeleteScheduledMessage instance to delete the given scheduled message.
+func NewDeleteScheduledMessage(channelID event.ChannelID, scheduledMessageID string) *DeleteScheduledMessage {
+	return &DeleteScheduledMessage{
+		ChannelID:          channelID,
+		ScheduledMessageID: scheduledMessageID,
+	}
+}
+
+// ScheduledMessage represents a message that is scheduled to be posted.
+type ScheduledMessage struct {
+	ID          string           `json:"id"`
+	ChannelID   event.ChannelID  `json:"channel_id"`
+	PostAt      *event.TimeStamp `json:"post_at"`
+	DateCreated *event.TimeStamp `json:"date_created"`
+	Text        string           `json:"text"`
+}
+
+// ScheduledMessagesResponse is a response of chat.scheduledMessages.list method.
+// See https://api.slack.com/methods/chat.scheduledMessages.list
+type ScheduledMessagesResponse struct {
+	APIResponse
+	ScheduledMessages []ScheduledMessage `json:"scheduled_messages"`
+}
+
+// ListScheduledMessages is a payload to be sent with chat.scheduledMessages.list method.
+// See https://api.slack.com/methods/chat.scheduledMessages.list
+type ListScheduledMessages struct {
+	ChannelID event.ChannelID `json:"channel,omitempty"`
+	Cursor    string          `json:"cursor,omitempty"`
+	Latest    string          `json:"latest,omitempty"`
+	Limit     int             `json:"limit,omitempty"`
+	Oldest    string          `json:"oldest,omitempty"`
+	TeamID    event.TeamID    `json:"team_id,omitempty"`
+}
+
+// WithChannelID limits the scheduled messages to those in the given channel.
+func (list *ListScheduledMessages) WithChannelID(channelID event.ChannelID) *ListScheduledMessages {
+	list.ChannelID = channelID
+	return list
+}
+
+// WithCursor sets the cursor given by the previous response to fetch the next page.
+func (list *ListScheduledMessages) WithCursor(cursor string) *ListScheduledMessages {
+	list.Cursor = cursor
+	return list
+}
+
+// WithLatest sets the end of the time range of scheduled messages to include.
+func (list *ListScheduledMessages) WithLatest(ts string) *ListScheduledMessages {
+	list.Latest = ts
+	return list
+}
+
+// WithLimit sets the maximum number of scheduled messages to return.
+func (list *ListScheduledMessages) WithLimit(limit int) *ListScheduledMessages {
+	list.Limit = limit
+	return list
+}
+
+// WithOldest sets the start of the time range of scheduled messages to include.
+func (list *ListScheduledMessages) WithOldest(ts string) *ListScheduledMessages {
+	list.Oldest = ts
+	return list
+}
+
+// NewListScheduledMessages creates ListScheduledMessages instance to list all scheduled messages.
+func NewListScheduledMessages() *ListScheduledMessages {
+	return &ListScheduledMessages{}
+}
+
+// PermalinkResponse is a response of chat.getPermalink method.
+// See https://api.slack.com/methods/chat.getPermalink
+type PermalinkResponse struct {
+	APIResponse
+	ChannelID event.ChannelID `json:"channel"`
+	Permalink string          `json:"permalink"`
+}
+
+// GetPermalink is a payload to be sent with chat.getPermalink method.
+// This method only accepts GET request, so the payload is passed as query parameters.
+// See https://api.slack.com/methods/chat.getPermalink
+type GetPermalink struct {
+	ChannelID        event.ChannelID
+	MessageTimeStamp string
+}
+
+var _ URLValuer = (*GetPermalink)(nil)
+
+// ToURLValues returns query parameters of the request.
+func (p *GetPermalink) ToURLValues() url.Values {
+	values := url.Values{}
+	values.Set("channel", p.ChannelID.String())
+	values.Set("message_ts", p.MessageTimeStamp)
+	return values
+}
+
+// NewGetPermalink creates GetPermalink instance to retrieve the permalink of the given message.
+func NewGetPermalink(channelID event.ChannelID, ts string) *GetPermalink {
+	return &GetPermalink{
+		ChannelID:        channelID,
+		MessageTimeStamp: ts,
+	}
+}
+
+// MeMessage is a payload to be sent with chat.meMessage method.
+// See https://api.slack.com/methods/chat.meMessage
+type MeMessage struct {
+	ChannelID event.ChannelID `json:"channel"`
+	Text      string          `json:"text"`
+}
+
+// NewMeMessage creates MeMessage instance with given channel and text settings.
+func NewMeMessage(channelID event.ChannelID, text string) *MeMessage {
+	return &MeMessage{
+		ChannelID: channelID,
+		Text:      text,
+	}
+}
@@ -0,0 +1,160 @@
+package webapi
+
+import (
+	"encoding/json"
+	"github.com/oklahomer/golack/v2/event"
+	"testing"
+	"time"
+)
+
+func TestChatResponse_UnmarshalJSON(t *testing.T) {
+	input := []byte(`{
+		"ok": true,
+		"channel": "C024BE91L",
+		"ts": "1401383885.000061",
+		"text": "Updated text you carefully authored",
+		"message": {"type": "message", "user": "U123", "text": "Updated text you carefully authored"}
+	}`)
+
+	response := &ChatResponse{}
+	err := json.Unmarshal(input, response)
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+
+	if response.ChannelID != "C024BE91L" {
+		t.Errorf("Channel is not decoded: %s.", response.ChannelID)
+	}
+
+	if response.TimeStamp.String() != "1401383885.000061" || response.TimeStamp.Time.Unix() != 1401383885 {
+		t.Errorf("Timestamp is not decoded: %#v.", response.TimeStamp)
+	}
+
+	if response.Message.UserID != "U123" {
+		t.Errorf("Message is not decoded: %#v.", response.Message)
+	}
+}
+
+func TestNewUpdateMessage(t *testing.T) {
+	blocks := []event.Block{&event.DividerBlock{}}
+	message := NewUpdateMessage("C123", "1401383885.000061", "updated").
+		WithAsUser(true).
+		WithBlocks(blocks).
+		WithLinkNames(1).
+		WithParse(ParseModeNone).
+		WithReplyBroadcast(true)
+
+	if message.ChannelID != "C123" || message.TimeStamp != "1401383885.000061" || message.Text != "updated" {
+		t.Errorf("Required values are not set: %#v.", message)
+	}
+
+	if !message.AsUser || len(message.Blocks) != 1 || message.LinkNames != 1 || message.Parse != ParseModeNone || !message.ReplyBroadcast {
+		t.Errorf("Optional values are not set: %#v.", message)
+	}
+}
+
+func TestNewDeleteMessage(t *testing.T) {
+	message := NewDeleteMessage("C123", "1401383885.000061").WithAsUser(true)
+
+	b, err := json.Marshal(message)
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+
+	if string(b) != `{"channel":"C123","ts":"1401383885.000061","as_user":true}` {
+		t.Errorf("Unexpected payload is built: %s.", string(b))
+	}
+}
+
+func TestNewPostEphemeral(t *testing.T) {
+	message := NewPostEphemeral("C123", "U123", "secret").
+		WithIconEmoji(":ghost:").
+		WithThreadTimeStamp("1401383885.000061").
+		WithUserName("bot")
+
+	if message.ChannelID != "C123" || message.UserID != "U123" || message.Text != "secret" {
+		t.Errorf("Required values are not set: %#v.", message)
+	}
+
+	if message.LinkNames != 1 || message.Parse != ParseModeFull {
+		t.Errorf("Default values are not set: %#v.", message)
+	}
+
+	if message.IconEmoji != ":ghost:" || message.ThreadTimeStamp != "1401383885.000061" || message.UserName != "bot" {
+		t.Errorf("Optional values are not set: %#v.", message)
+	}
+}
+
+func TestNewScheduleMessage(t *testing.T) {
+	postAt := time.Unix(1551891428, 0)
+	message := NewScheduleMessage("C123", postAt, "later").WithThreadTimeStamp("1401383885.000061").WithUnfurlLinks(false)
+
+	if message.PostAt != 1551891428 {
+		t.Errorf("Expected post_at is not set: %d.", message.PostAt)
+	}
+
+	if message.ThreadTimeStamp != "1401383885.000061" || message.UnfurlLinks {
+		t.Errorf("Optional values are not set: %#v.", message)
+	}
+}
+
+func TestScheduleMessageResponse_UnmarshalJSON(t *testing.T) {
+	input := []byte(`{
+		"ok": true,
+		"channel": "C1H9RESGL",
+		"scheduled_message_id": "Q1298393284",
+		"post_at": "1562180400",
+		"message": {"text": "Here's a message for you in the future", "type": "delayed_message"}
+	}`)
+
+	response := &ScheduleMessageResponse{}
+	err := json.Unmarshal(input, response)
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+
+	if response.ScheduledMessageID != "Q1298393284" || response.PostAt.Time.Unix() != 1562180400 {
+		t.Errorf("Response is not decoded: %#v.", response)
+	}
+}
+
+func TestNewDeleteScheduledMessage(t *testing.T) {
+	message := NewDeleteScheduledMessage("C123", "Q1298393284")
+
+	if message.ChannelID != "C123" || message.ScheduledMessageID != "Q1298393284" {
+		t.Errorf("Required values are not set: %#v.", message)
+	}
+}
+
+func TestNewListScheduledMessages(t *testing.T) {
+	list := NewListScheduledMessages()
+
+	b, err := json.Marshal(list)
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+	if string(b) != `{}` {
+		t.Errorf("Unset values are given: %s.", string(b))
+	}
+
+	list.WithChannelID("C123").WithCursor("abc").WithLimit(10)
+	if list.ChannelID != "C123" || list.Cursor != "abc" || list.Limit != 10 {
+		t.Errorf("Optional values are not set: %#v.", list)
+	}
+}
+
+func TestGetPermalink_ToURLValues(t *testing.T) {
+	values := NewGetPermalink("C123", "1401383885.000061").ToURLValues()
+
+	if values.Get("channel") != "C123" || values.Get("message_ts") != "1401383885.000061" {
+		t.Errorf("Expected values are not set: %s.", values.Encode())
+	}
+}
+
+func TestNewMeMessage(t *testing.T) {
+	message := NewMeMessage("C123", "is happy")
+
+	if message.ChannelID != "C123" || message.Text != "is happy" {
+		t.Errorf("Expected values are not set: %#v.", message)
+	}
+}
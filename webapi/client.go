@@ -5,9 +5,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/tidwall/gjson"
+	"io/ioutil"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"reflect"
 	"time"
 )
 
@@ -22,13 +25,28 @@ type URLValuer interface {
 type Config struct {
 	Token          string        `json:"token" yaml:"token"`
 	RequestTimeout time.Duration `json:"request_timeout" yaml:"request_timeout"`
+
+	// MethodTimeouts overrides RequestTimeout for specific methods that take longer such as files.upload.
+	MethodTimeouts map[string]time.Duration `json:"method_timeouts" yaml:"method_timeouts"`
 }
 
 func NewConfig() *Config {
 	return &Config{
 		Token:          "",
 		RequestTimeout: 3 * time.Second,
+		MethodTimeouts: map[string]time.Duration{
+			"conversations.history": 10 * time.Second,
+			"files.upload":          60 * time.Second,
+		},
+	}
+}
+
+// timeout returns the timeout of a single request to the given method.
+func (c *Config) timeout(slackMethod string) time.Duration {
+	if timeout, ok := c.MethodTimeouts[slackMethod]; ok {
+		return timeout
 	}
+	return c.RequestTimeout
 }
 
 type ClientOption func(*Client)
@@ -40,8 +58,12 @@ func WithHTTPClient(httpClient *http.Client) ClientOption {
 }
 
 type Client struct {
-	config     *Config
-	httpClient *http.Client
+	config           *Config
+	httpClient       *http.Client
+	rateLimitRetries int
+	throttler        *throttler
+	retryPolicy      *RetryPolicy
+	tokenProvider    TokenProvider
 }
 
 func NewClient(config *Config, options ...ClientOption) *Client {
@@ -70,37 +92,61 @@ func buildEndpoint(slackMethod string, queryParams url.Values) *url.URL {
 	return requestURL
 }
 
-func (client *Client) Get(ctx context.Context, slackMethod string, queryParams url.Values, response interface{}) error {
-	// Prepare request
-	endpoint := buildEndpoint(slackMethod, queryParams)
-	req, err := http.NewRequest(http.MethodGet, endpoint.String(), nil)
-	if err != nil {
-		return err
+// token returns the token to send with the request along with the provider to refresh it.
+// The provider is nil when the token is fixed.
+// The token carried by the context takes precedence over the client's settings.
+func (client *Client) token(ctx context.Context) (string, TokenProvider, error) {
+	provider := client.tokenProvider
+	if t, ok := ctx.Value(tokenContextKey{}).(*contextToken); ok {
+		if t.provider == nil {
+			return t.token, nil, nil
+		}
+		provider = t.provider
 	}
-	reqCtx, cancel := context.WithTimeout(ctx, client.config.RequestTimeout)
-	defer cancel()
-	req.WithContext(reqCtx)
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", client.config.Token))
 
-	// Do request
-	resp, err := client.httpClient.Do(req)
+	if provider == nil {
+		return client.config.Token, nil, nil
+	}
+
+	token, err := provider.Token(ctx)
 	if err != nil {
-		return err
+		return "", nil, err
 	}
-	defer resp.Body.Close()
+	return token, provider, nil
+}
 
-	// Usually, the API returns a JSON structure with status code 200.
-	// https://api.slack.com/web#evaluating_responses
-	if resp.StatusCode != http.StatusOK {
-		return statusErr(resp)
+// authorize sets the given token to the request.
+// The header is omitted when no token is given so methods such as oauth.v2.access can be called with client credentials only.
+func authorize(req *http.Request, token string) {
+	if token == "" {
+		return
 	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+}
 
-	// Handle response body
-	err = json.NewDecoder(resp.Body).Decode(&response)
-	if err != nil {
-		return err
+func (client *Client) Get(ctx context.Context, slackMethod string, queryParams url.Values, response interface{}) error {
+	// Prepare request
+	endpoint := buildEndpoint(slackMethod, queryParams)
+	newRequest := func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodGet, endpoint.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		return req, nil
 	}
-	return nil
+
+	return client.do(ctx, slackMethod, queryParams.Get("channel"), newRequest, response)
+}
+
+// StatusError is returned when Web API responds with an unexpected HTTP status.
+type StatusError struct {
+	StatusCode int
+	Err        string
+}
+
+// Error returns its error string.
+func (e *StatusError) Error() string {
+	return e.Err
 }
 
 func statusErr(resp *http.Response) error {
@@ -117,10 +163,18 @@ func statusErr(resp *http.Response) error {
 		resDump = []byte("N/A")
 	}
 
-	return fmt.Errorf("response status error. Status: %d.\nRequest: %s\nResponse: %s", resp.StatusCode, string(reqDump), string(resDump))
+	return &StatusError{
+		StatusCode: resp.StatusCode,
+		Err:        fmt.Sprintf("response status error. Status: %d.\nRequest: %s\nResponse: %s", resp.StatusCode, string(reqDump), string(resDump)),
+	}
 }
 
 func (client *Client) Post(ctx context.Context, slackMethod string, payload interface{}, response interface{}) error {
+	// A file is streamed as multipart/form-data instead of being serialized beforehand
+	if upload, ok := payload.(*FileUpload); ok {
+		return client.postMultipart(ctx, slackMethod, upload, response)
+	}
+
 	// Decide how the request should be treated depending on the slackMethod/payload
 	p, err := genPayload(slackMethod, payload)
 	if err != nil {
@@ -129,15 +183,111 @@ func (client *Client) Post(ctx context.Context, slackMethod string, payload inte
 
 	// Prepare request
 	endpoint := buildEndpoint(slackMethod, nil)
-	req, err := http.NewRequest("POST", endpoint.String(), bytes.NewReader(p.Body))
+	newRequest := func() (*http.Request, error) {
+		// A new body reader is required for every attempt
+		req, err := http.NewRequest("POST", endpoint.String(), bytes.NewReader(p.Body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", p.Type)
+		return req, nil
+	}
+
+	return client.do(ctx, slackMethod, p.channel(), newRequest, response)
+}
+
+// do sends a request built by newRequest and decodes the response.
+// When HTTP 429 is returned, this waits for the duration given by Retry-After header and tries again as long as the retry budget allows.
+// Other failures are retried as the RetryPolicy allows.
+func (client *Client) do(ctx context.Context, slackMethod string, channel string, newRequest func() (*http.Request, error), response interface{}) error {
+	rateLimitRetried := 0
+	attempt := 1
+	tokenRefreshed := false
+	for {
+		if client.throttler != nil {
+			err := client.throttler.wait(ctx, slackMethod, channel)
+			if err != nil {
+				return err
+			}
+		}
+
+		token, provider, err := client.token(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = client.request(ctx, slackMethod, token, newRequest, response)
+		if err == nil {
+			return nil
+		}
+
+		// The request is rejected before taking effect, so this is safe to retry once with a refreshed token
+		if apiErr, ok := err.(*APIError); ok && apiErr.Code == "token_expired" && provider != nil && !tokenRefreshed {
+			tokenRefreshed = true
+			_, err = provider.Refresh(ctx, token)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		var wait time.Duration
+		if rateLimited, ok := err.(*RateLimitedError); ok {
+			if client.throttler != nil {
+				// Hold other requests to the same method as well
+				client.throttler.pause(slackMethod, channel, rateLimited.RetryAfter)
+			}
+
+			if rateLimitRetried >= client.rateLimitRetries {
+				return rateLimited
+			}
+			rateLimitRetried++
+			wait = rateLimited.RetryAfter
+		} else {
+			if !client.retryPolicy.retryable(ctx, slackMethod, attempt, err) {
+				if _, ok := err.(*APIError); ok {
+					// The response is already decoded. Let the caller check the error code as usual.
+					return nil
+				}
+				return err
+			}
+			wait = client.retryPolicy.interval(attempt)
+		}
+
+		client.retryPolicy.notify(&RetryEvent{
+			Method:  slackMethod,
+			Attempt: attempt,
+			Wait:    wait,
+			Err:     err,
+		})
+		attempt++
+
+		err = sleep(ctx, wait)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// request sends a single request and decodes the response.
+// *APIError is returned when the response is decoded but represents a failure, so the caller can decide whether to retry.
+func (client *Client) request(ctx context.Context, slackMethod string, token string, newRequest func() (*http.Request, error), response interface{}) error {
+	req, err := newRequest()
 	if err != nil {
 		return err
 	}
-	req.Header.Set("Content-Type", p.Type)
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", client.config.Token))
-	reqCtx, cancel := context.WithTimeout(ctx, client.config.RequestTimeout)
+	authorize(req, token)
+
+	// Apply the timeout to each attempt while respecting the caller's context
+	var reqCtx context.Context
+	var cancel context.CancelFunc
+	if timeout := client.config.timeout(slackMethod); timeout > 0 {
+		reqCtx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		reqCtx, cancel = context.WithCancel(ctx)
+	}
 	defer cancel()
-	req.WithContext(reqCtx)
+	req = req.WithContext(reqCtx)
 
 	// Do request
 	resp, err := client.httpClient.Do(req)
@@ -146,6 +296,15 @@ func (client *Client) Post(ctx context.Context, slackMethod string, payload inte
 	}
 	defer resp.Body.Close()
 
+	// Slack returns HTTP 429 when the rate limit is exceeded.
+	// https://api.slack.com/docs/rate-limits
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return &RateLimitedError{
+			Method:     slackMethod,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+	}
+
 	// Usually, the API returns a JSON structure with status code 200.
 	// https://api.slack.com/web#evaluating_responses
 	if resp.StatusCode != http.StatusOK {
@@ -153,10 +312,27 @@ func (client *Client) Post(ctx context.Context, slackMethod string, payload inte
 	}
 
 	// Handle response body
-	err = json.NewDecoder(resp.Body).Decode(&response)
+	b, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return err
 	}
+
+	// Reset the response so fields of a previously failed attempt do not remain
+	if v := reflect.ValueOf(response); v.Kind() == reflect.Ptr && !v.IsNil() {
+		v.Elem().Set(reflect.Zero(v.Elem().Type()))
+	}
+
+	err = json.Unmarshal(b, &response)
+	if err != nil {
+		return err
+	}
+
+	apiResponse := &APIResponse{}
+	err = json.Unmarshal(b, apiResponse)
+	if err == nil && !apiResponse.OK {
+		return NewAPIError(slackMethod, apiResponse)
+	}
+
 	return nil
 }
 
@@ -200,3 +376,16 @@ type payload struct {
 	Type string
 	Body []byte
 }
+
+// channel returns the channel ID in the payload, if any, to apply the per-channel rate limit.
+func (p *payload) channel() string {
+	if p.Type == "application/json" {
+		return gjson.GetBytes(p.Body, "channel").String()
+	}
+
+	values, err := url.ParseQuery(string(p.Body))
+	if err != nil {
+		return ""
+	}
+	return values.Get("channel")
+}
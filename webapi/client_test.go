@@ -3,6 +3,7 @@ package webapi
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -27,6 +28,10 @@ func TestNewConfig(t *testing.T) {
 	if config.RequestTimeout == 0 {
 		t.Error("Default timeout is not set.")
 	}
+
+	if config.MethodTimeouts["files.upload"] <= config.RequestTimeout {
+		t.Error("Longer timeout is not set for files.upload.")
+	}
 }
 
 func TestWithHTTPClient(t *testing.T) {
@@ -446,3 +451,125 @@ func (l *localRoundTripper) RoundTrip(req *http.Request) (*http.Response, error)
 	l.mux.ServeHTTP(w, req)
 	return w.Result(), nil
 }
+
+// rewriteRoundTripper sends every request to the given server instead of slack.com.
+type rewriteRoundTripper struct {
+	target *url.URL
+}
+
+func (r *rewriteRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Leave the given request untouched so http.Client still sees the original URL
+	rewritten := new(http.Request)
+	*rewritten = *req
+	u := *req.URL
+	u.Scheme = r.target.Scheme
+	u.Host = r.target.Host
+	rewritten.URL = &u
+	return http.DefaultTransport.RoundTrip(rewritten)
+}
+
+func TestClient_Timeout(t *testing.T) {
+	// A server that does not respond until the request is canceled
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		select {
+		case <-req.Context().Done():
+		case <-time.After(3 * time.Second):
+		}
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+	target, _ := url.Parse(server.URL)
+
+	newClient := func(config *Config) *Client {
+		return NewClient(config, WithHTTPClient(&http.Client{Transport: &rewriteRoundTripper{target: target}}))
+	}
+
+	t.Run("caller's context", func(t *testing.T) {
+		client := newClient(&Config{RequestTimeout: 10 * time.Second})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		start := time.Now()
+		err := client.Get(ctx, "conversations.info", nil, &APIResponse{})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected error is not returned: %#v.", err)
+		}
+
+		if time.Since(start) > 1*time.Second {
+			t.Errorf("Request is not canceled: %s.", time.Since(start))
+		}
+	})
+
+	t.Run("request timeout", func(t *testing.T) {
+		client := newClient(&Config{RequestTimeout: 50 * time.Millisecond})
+
+		start := time.Now()
+		err := client.Post(context.TODO(), "conversations.info", url.Values{}, &APIResponse{})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected error is not returned: %#v.", err)
+		}
+
+		if time.Since(start) > 1*time.Second {
+			t.Errorf("Request is not timed out: %s.", time.Since(start))
+		}
+	})
+
+	t.Run("method timeout", func(t *testing.T) {
+		client := newClient(&Config{
+			RequestTimeout: 10 * time.Second,
+			MethodTimeouts: map[string]time.Duration{"conversations.history": 50 * time.Millisecond},
+		})
+
+		start := time.Now()
+		err := client.Get(context.TODO(), "conversations.history", nil, &APIResponse{})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected error is not returned: %#v.", err)
+		}
+
+		if time.Since(start) > 1*time.Second {
+			t.Errorf("Method timeout is not applied: %s.", time.Since(start))
+		}
+	})
+}
+
+func TestConfig_timeout(t *testing.T) {
+	config := &Config{
+		RequestTimeout: 3 * time.Second,
+		MethodTimeouts: map[string]time.Duration{"files.upload": 1 * time.Minute},
+	}
+
+	if config.timeout("files.upload") != 1*time.Minute {
+		t.Errorf("Method timeout is not returned: %s.", config.timeout("files.upload"))
+	}
+
+	if config.timeout("chat.postMessage") != 3*time.Second {
+		t.Errorf("Default timeout is not returned: %s.", config.timeout("chat.postMessage"))
+	}
+}
+
+func Test_authorize(t *testing.T) {
+	tests := []struct {
+		token    string
+		expected string
+	}{
+		{
+			token:    "abc",
+			expected: "Bearer abc",
+		},
+		{
+			// Methods such as oauth.v2.access are called without a token
+			token:    "",
+			expected: "",
+		},
+	}
+
+	for i, tt := range tests {
+		req, _ := http.NewRequest(http.MethodPost, "https://slack.com/api/oauth.v2.access", nil)
+		authorize(req, tt.token)
+
+		if req.Header.Get(AuthHeaderName) != tt.expected {
+			t.Errorf("Unexpected header is set on test #%d: %s.", i, req.Header.Get(AuthHeaderName))
+		}
+	}
+}
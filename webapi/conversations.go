@@ -0,0 +1,360 @@
+package webapi
+
+import (
+	"github.com/oklahomer/golack/v2/event"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Conversation represents a channel-like object such as a public channel, a private channel, a direct message or a multi-person direct message.
+// https://api.slack.com/types/conversation
+type Conversation struct {
+	ID                 event.ChannelID      `json:"id"`
+	Name               string               `json:"name"`
+	NameNormalized     string               `json:"name_normalized"`
+	Created            *event.TimeStamp     `json:"created"`
+	Creator            event.UserID         `json:"creator"`
+	IsChannel          bool                 `json:"is_channel"`
+	IsGroup            bool                 `json:"is_group"`
+	IsIM               bool                 `json:"is_im"`
+	IsMpIM             bool                 `json:"is_mpim"`
+	IsPrivate          bool                 `json:"is_private"`
+	IsArchived         bool                 `json:"is_archived"`
+	IsGeneral          bool                 `json:"is_general"`
+	IsShared           bool                 `json:"is_shared"`
+	IsExtShared        bool                 `json:"is_ext_shared"`
+	IsOrgShared        bool                 `json:"is_org_shared"`
+	IsMember           bool                 `json:"is_member"`
+	IsOpen             bool                 `json:"is_open"`
+	UserID             event.UserID         `json:"user"`
+	LastRead           *event.TimeStamp     `json:"last_read"`
+	Latest             *ConversationMessage `json:"latest"`
+	UnreadCount        int                  `json:"unread_count"`
+	UnreadCountDisplay int                  `json:"unread_count_display"`
+	NumMembers         int                  `json:"num_members"`
+	Locale             string               `json:"locale"`
+	Topic              *ConversationTopic   `json:"topic"`
+	Purpose            *ConversationTopic   `json:"purpose"`
+}
+
+// ConversationTopic represents the topic or the purpose of a conversation.
+type ConversationTopic struct {
+	Value   string           `json:"value"`
+	Creator event.UserID     `json:"creator"`
+	LastSet *event.TimeStamp `json:"last_set"`
+}
+
+// ConversationMessage represents a message returned by conversations.history and conversations.replies.
+// https://api.slack.com/events/message
+type ConversationMessage struct {
+	Type            string           `json:"type"`
+	SubType         string           `json:"subtype"`
+	UserID          event.UserID     `json:"user"`
+	BotID           event.BotID      `json:"bot_id"`
+	Text            string           `json:"text"`
+	TimeStamp       *event.TimeStamp `json:"ts"`
+	ThreadTimeStamp *event.TimeStamp `json:"thread_ts"`
+	ReplyCount      int              `json:"reply_count"`
+	ReplyUsers      []event.UserID   `json:"reply_users"`
+	LatestReply     *event.TimeStamp `json:"latest_reply"`
+	Reactions       []*Reaction      `json:"reactions"`
+}
+
+// ConversationResponse is a response of methods that return a single conversation such as conversations.info and conversations.create.
+type ConversationResponse struct {
+	APIResponse
+	Channel *Conversation `json:"channel"`
+
+	// NoOp, AlreadyOpen and AlreadyInChannel are given when the operation was not required.
+	NoOp             bool `json:"no_op"`
+	AlreadyOpen      bool `json:"already_open"`
+	AlreadyInChannel bool `json:"already_in_channel"`
+}
+
+// ConversationsHistory is a response of conversations.history method.
+// https://api.slack.com/methods/conversations.history
+type ConversationsHistory struct {
+	APIResponse
+	Messages []ConversationMessage `json:"messages"`
+	HasMore  bool                  `json:"has_more"`
+}
+
+// ConversationsReplies is a response of conversations.replies method.
+// The first message is the parent message of the thread.
+// https://api.slack.com/methods/conversations.replies
+type ConversationsReplies struct {
+	APIResponse
+	Messages []ConversationMessage `json:"messages"`
+	HasMore  bool                  `json:"has_more"`
+}
+
+// ConversationsInfoRequest is a payload to be sent with conversations.info method.
+// See https://api.slack.com/methods/conversations.info
+type ConversationsInfoRequest struct {
+	ChannelID         event.ChannelID
+	IncludeLocale     bool
+	IncludeNumMembers bool
+}
+
+var _ URLValuer = (*ConversationsInfoRequest)(nil)
+
+// NewConversationsInfoRequest creates ConversationsInfoRequest with the given channel.
+func NewConversationsInfoRequest(channelID event.ChannelID) *ConversationsInfoRequest {
+	return &ConversationsInfoRequest{
+		ChannelID: channelID,
+	}
+}
+
+// WithIncludeLocale sets optional boolean value to include the locale of the conversation.
+func (req *ConversationsInfoRequest) WithIncludeLocale(flg bool) *ConversationsInfoRequest {
+	req.IncludeLocale = flg
+	return req
+}
+
+// WithIncludeNumMembers sets optional boolean value to include the number of members in the conversation.
+func (req *ConversationsInfoRequest) WithIncludeNumMembers(flg bool) *ConversationsInfoRequest {
+	req.IncludeNumMembers = flg
+	return req
+}
+
+// ToURLValues returns query parameters of the request.
+func (req *ConversationsInfoRequest) ToURLValues() url.Values {
+	values := url.Values{}
+	values.Set("channel", req.ChannelID.String())
+	if req.IncludeLocale {
+		values.Set("include_locale", "true")
+	}
+	if req.IncludeNumMembers {
+		values.Set("include_num_members", "true")
+	}
+	return values
+}
+
+// ConversationsHistoryRequest is a payload to be sent with conversations.history method.
+// See https://api.slack.com/methods/conversations.history
+type ConversationsHistoryRequest struct {
+	ChannelID event.ChannelID
+	Cursor    string
+	Inclusive bool
+	Latest    string
+	Limit     int
+	Oldest    string
+}
+
+var _ URLValuer = (*ConversationsHistoryRequest)(nil)
+
+// NewConversationsHistoryRequest creates ConversationsHistoryRequest with the given channel.
+func NewConversationsHistoryRequest(channelID event.ChannelID) *ConversationsHistoryRequest {
+	return &ConversationsHistoryRequest{
+		ChannelID: channelID,
+	}
+}
+
+// WithCursor sets the cursor given by the previous response to fetch the next page.
+func (req *ConversationsHistoryRequest) WithCursor(cursor string) *ConversationsHistoryRequest {
+	req.Cursor = cursor
+	return req
+}
+
+// WithInclusive sets optional boolean value to include messages with latest or oldest timestamp.
+func (req *ConversationsHistoryRequest) WithInclusive(flg bool) *ConversationsHistoryRequest {
+	req.Inclusive = flg
+	return req
+}
+
+// WithLatest sets the end of the time range of messages to include.
+func (req *ConversationsHistoryRequest) WithLatest(ts string) *ConversationsHistoryRequest {
+	req.Latest = ts
+	return req
+}
+
+// WithLimit sets the maximum number of messages to return.
+func (req *ConversationsHistoryRequest) WithLimit(limit int) *ConversationsHistoryRequest {
+	req.Limit = limit
+	return req
+}
+
+// WithOldest sets the start of the time range of messages to include.
+func (req *ConversationsHistoryRequest) WithOldest(ts string) *ConversationsHistoryRequest {
+	req.Oldest = ts
+	return req
+}
+
+// ToURLValues returns query parameters of the request.
+func (req *ConversationsHistoryRequest) ToURLValues() url.Values {
+	values := url.Values{}
+	values.Set("channel", req.ChannelID.String())
+	setRangeValues(values, req.Cursor, req.Inclusive, req.Latest, req.Limit, req.Oldest)
+	return values
+}
+
+// ConversationsRepliesRequest is a payload to be sent with conversations.replies method.
+// See https://api.slack.com/methods/conversations.replies
+type ConversationsRepliesRequest struct {
+	ChannelID event.ChannelID
+	TimeStamp string
+	Cursor    string
+	Inclusive bool
+	Latest    string
+	Limit     int
+	Oldest    string
+}
+
+var _ URLValuer = (*ConversationsRepliesRequest)(nil)
+
+// NewConversationsRepliesRequest creates ConversationsRepliesRequest with the given channel and the timestamp of the parent message.
+func NewConversationsRepliesRequest(channelID event.ChannelID, ts string) *ConversationsRepliesRequest {
+	return &ConversationsRepliesRequest{
+		ChannelID: channelID,
+		TimeStamp: ts,
+	}
+}
+
+// WithCursor sets the cursor given by the previous response to fetch the next page.
+func (req *ConversationsRepliesRequest) WithCursor(cursor string) *ConversationsRepliesRequest {
+	req.Cursor = cursor
+	return req
+}
+
+// WithInclusive sets optional boolean value to include messages with latest or oldest timestamp.
+func (req *ConversationsRepliesRequest) WithInclusive(flg bool) *ConversationsRepliesRequest {
+	req.Inclusive = flg
+	return req
+}
+
+// WithLatest sets the end of the time range of messages to include.
+func (req *ConversationsRepliesRequest) WithLatest(ts string) *ConversationsRepliesRequest {
+	req.Latest = ts
+	return req
+}
+
+// WithLimit sets the maximum number of messages to return.
+func (req *ConversationsRepliesRequest) WithLimit(limit int) *ConversationsRepliesRequest {
+	req.Limit = limit
+	return req
+}
+
+// WithOldest sets the start of the time range of messages to include.
+func (req *ConversationsRepliesRequest) WithOldest(ts string) *ConversationsRepliesRequest {
+	req.Oldest = ts
+	return req
+}
+
+// ToURLValues returns query parameters of the request.
+func (req *ConversationsRepliesRequest) ToURLValues() url.Values {
+	values := url.Values{}
+	values.Set("channel", req.ChannelID.String())
+	values.Set("ts", req.TimeStamp)
+	setRangeValues(values, req.Cursor, req.Inclusive, req.Latest, req.Limit, req.Oldest)
+	return values
+}
+
+func setRangeValues(values url.Values, cursor string, inclusive bool, latest string, limit int, oldest string) {
+	if cursor != "" {
+		values.Set("cursor", cursor)
+	}
+	if inclusive {
+		values.Set("inclusive", "true")
+	}
+	if latest != "" {
+		values.Set("latest", latest)
+	}
+	if limit > 0 {
+		values.Set("limit", strconv.Itoa(limit))
+	}
+	if oldest != "" {
+		values.Set("oldest", oldest)
+	}
+}
+
+// ConversationsCreateRequest is a payload to be sent with conversations.create method.
+// See https://api.slack.com/methods/conversations.create
+type ConversationsCreateRequest struct {
+	Name      string       `json:"name"`
+	IsPrivate bool         `json:"is_private,omitempty"`
+	TeamID    event.TeamID `json:"team_id,omitempty"`
+}
+
+// ConversationsInviteRequest is a payload to be sent with conversations.invite method.
+// See https://api.slack.com/methods/conversations.invite
+type ConversationsInviteRequest struct {
+	ChannelID event.ChannelID `json:"channel"`
+
+	// Users is a comma separated list of user IDs.
+	Users string `json:"users"`
+}
+
+// NewConversationsInviteRequest creates ConversationsInviteRequest to invite the given users to the channel.
+func NewConversationsInviteRequest(channelID event.ChannelID, userIDs ...event.UserID) *ConversationsInviteRequest {
+	return &ConversationsInviteRequest{
+		ChannelID: channelID,
+		Users:     joinUserIDs(userIDs),
+	}
+}
+
+// ConversationsKickRequest is a payload to be sent with conversations.kick method.
+// See https://api.slack.com/methods/conversations.kick
+type ConversationsKickRequest struct {
+	ChannelID event.ChannelID `json:"channel"`
+	UserID    event.UserID    `json:"user"`
+}
+
+// ConversationsRenameRequest is a payload to be sent with conversations.rename method.
+// See https://api.slack.com/methods/conversations.rename
+type ConversationsRenameRequest struct {
+	ChannelID event.ChannelID `json:"channel"`
+	Name      string          `json:"name"`
+}
+
+// ConversationsSetTopicRequest is a payload to be sent with conversations.setTopic method.
+// See https://api.slack.com/methods/conversations.setTopic
+type ConversationsSetTopicRequest struct {
+	ChannelID event.ChannelID `json:"channel"`
+	Topic     string          `json:"topic"`
+}
+
+// ConversationsSetPurposeRequest is a payload to be sent with conversations.setPurpose method.
+// See https://api.slack.com/methods/conversations.setPurpose
+type ConversationsSetPurposeRequest struct {
+	ChannelID event.ChannelID `json:"channel"`
+	Purpose   string          `json:"purpose"`
+}
+
+// ConversationsOpenRequest is a payload to be sent with conversations.open method.
+// Either ChannelID to resume a conversation or Users to open a new one is required.
+// See https://api.slack.com/methods/conversations.open
+type ConversationsOpenRequest struct {
+	ChannelID event.ChannelID `json:"channel,omitempty"`
+	ReturnIM  bool            `json:"return_im,omitempty"`
+
+	// Users is a comma separated list of user IDs.
+	Users string `json:"users,omitempty"`
+}
+
+// NewConversationsOpenRequest creates ConversationsOpenRequest to open a direct message or a multi-person direct message with the given users.
+func NewConversationsOpenRequest(userIDs ...event.UserID) *ConversationsOpenRequest {
+	return &ConversationsOpenRequest{
+		Users: joinUserIDs(userIDs),
+	}
+}
+
+// WithReturnIM sets optional boolean value to return the full direct message channel definition.
+func (req *ConversationsOpenRequest) WithReturnIM(flg bool) *ConversationsOpenRequest {
+	req.ReturnIM = flg
+	return req
+}
+
+// ChannelRequest is a payload for methods that only take a channel
+// such as conversations.join, conversations.leave, conversations.archive, conversations.unarchive and conversations.close.
+type ChannelRequest struct {
+	ChannelID event.ChannelID `json:"channel"`
+}
+
+func joinUserIDs(userIDs []event.UserID) string {
+	ids := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		ids[i] = id.String()
+	}
+	return strings.Join(ids, ",")
+}
@@ -0,0 +1,126 @@
+package webapi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestConversation_UnmarshalJSON(t *testing.T) {
+	input := []byte(`{
+		"id": "C012AB3CD",
+		"name": "general",
+		"created": 1449252889,
+		"creator": "W012A3BCD",
+		"is_channel": true,
+		"is_member": true,
+		"last_read": "1502126650.228446",
+		"topic": {"value": "For public discussion", "creator": "W012A3BCD", "last_set": 1449709364},
+		"purpose": {"value": "This part of the workspace is for fun.", "creator": "W012A3BCD", "last_set": 1449709352}
+	}`)
+
+	conversation := &Conversation{}
+	err := json.Unmarshal(input, conversation)
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+
+	if conversation.ID != "C012AB3CD" || conversation.Creator != "W012A3BCD" {
+		t.Errorf("IDs are not decoded: %#v.", conversation)
+	}
+
+	if conversation.Created.Time.Unix() != 1449252889 {
+		t.Errorf("Created is not decoded: %#v.", conversation.Created)
+	}
+
+	if conversation.LastRead.String() != "1502126650.228446" {
+		t.Errorf("LastRead is not decoded: %#v.", conversation.LastRead)
+	}
+
+	if conversation.Topic.Value != "For public discussion" || conversation.Topic.LastSet.Time.Unix() != 1449709364 {
+		t.Errorf("Topic is not decoded: %#v.", conversation.Topic)
+	}
+}
+
+func TestConversationsInfoRequest_ToURLValues(t *testing.T) {
+	req := NewConversationsInfoRequest("C123").WithIncludeLocale(true).WithIncludeNumMembers(true)
+	values := req.ToURLValues()
+
+	if values.Get("channel") != "C123" {
+		t.Errorf("Channel is not set: %s.", values.Encode())
+	}
+
+	if values.Get("include_locale") != "true" || values.Get("include_num_members") != "true" {
+		t.Errorf("Optional values are not set: %s.", values.Encode())
+	}
+
+	values = NewConversationsInfoRequest("C123").ToURLValues()
+	if _, ok := values["include_locale"]; ok {
+		t.Errorf("Unset value is given: %s.", values.Encode())
+	}
+}
+
+func TestConversationsHistoryRequest_ToURLValues(t *testing.T) {
+	req := NewConversationsHistoryRequest("C123").
+		WithCursor("abc").
+		WithInclusive(true).
+		WithLatest("1512085950.000216").
+		WithLimit(100).
+		WithOldest("1512085900.000000")
+	values := req.ToURLValues()
+
+	expected := map[string]string{
+		"channel":   "C123",
+		"cursor":    "abc",
+		"inclusive": "true",
+		"latest":    "1512085950.000216",
+		"limit":     "100",
+		"oldest":    "1512085900.000000",
+	}
+	for key, value := range expected {
+		if values.Get(key) != value {
+			t.Errorf("Expected %s is not set: %s.", key, values.Encode())
+		}
+	}
+}
+
+func TestConversationsRepliesRequest_ToURLValues(t *testing.T) {
+	req := NewConversationsRepliesRequest("C123", "1512085950.000216").WithLimit(10)
+	values := req.ToURLValues()
+
+	if values.Get("channel") != "C123" || values.Get("ts") != "1512085950.000216" {
+		t.Errorf("Required values are not set: %s.", values.Encode())
+	}
+
+	if values.Get("limit") != "10" {
+		t.Errorf("Limit is not set: %s.", values.Encode())
+	}
+
+	if _, ok := values["cursor"]; ok {
+		t.Errorf("Unset value is given: %s.", values.Encode())
+	}
+}
+
+func TestNewConversationsInviteRequest(t *testing.T) {
+	req := NewConversationsInviteRequest("C123", "U1", "U2")
+
+	if req.ChannelID != "C123" {
+		t.Errorf("Channel is not set: %s.", req.ChannelID)
+	}
+
+	if req.Users != "U1,U2" {
+		t.Errorf("Users are not set: %s.", req.Users)
+	}
+}
+
+func TestNewConversationsOpenRequest(t *testing.T) {
+	req := NewConversationsOpenRequest("U1", "U2").WithReturnIM(true)
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+
+	if string(b) != `{"return_im":true,"users":"U1,U2"}` {
+		t.Errorf("Unexpected payload is built: %s.", string(b))
+	}
+}
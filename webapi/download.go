@@ -0,0 +1,195 @@
+package webapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/oklahomer/golack/v2/event"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultMaxDownloadSize is the maximum size of the file content that DownloadFile and Download accept by default.
+const DefaultMaxDownloadSize int64 = 100 << 20
+
+var (
+	// ErrNonSlackHost is returned when the given URL is not served by Slack over HTTPS, so the token cannot be sent.
+	ErrNonSlackHost = errors.New("refusing to send the token to a host other than slack.com over HTTPS")
+
+	// ErrFileTooLarge is returned when the file content exceeds the maximum size.
+	// The content that is already written to the io.Writer should be discarded.
+	ErrFileTooLarge = errors.New("file content exceeds the maximum size")
+
+	// ErrContentMismatch is returned when the size or the MIME type of the content differs from the expected one.
+	// This typically happens when Slack returns a login page instead of the file content due to the lack of files:read scope.
+	ErrContentMismatch = errors.New("unexpected file content")
+)
+
+type downloadOption struct {
+	maxSize  int64
+	size     int64
+	mimeType string
+}
+
+// DownloadOption defines a function that customizes the file download.
+type DownloadOption func(*downloadOption)
+
+// WithMaxSize sets the maximum size of the file content in bytes.
+// DefaultMaxDownloadSize is applied by default.
+func WithMaxSize(size int64) DownloadOption {
+	return func(o *downloadOption) {
+		o.maxSize = size
+	}
+}
+
+// WithExpectedSize sets the expected size of the file content in bytes.
+// DownloadFile sets event.File.Size by default.
+func WithExpectedSize(size int64) DownloadOption {
+	return func(o *downloadOption) {
+		o.size = size
+	}
+}
+
+// WithExpectedMIMEType sets the expected MIME type of the file content such as "image/png".
+// DownloadFile sets event.File.MimeType by default. Give an empty string to skip the verification.
+func WithExpectedMIMEType(mimeType string) DownloadOption {
+	return func(o *downloadOption) {
+		o.mimeType = mimeType
+	}
+}
+
+// DownloadFile downloads the content of the given file and writes it to the given io.Writer.
+// URLPrivateDownload is used when available, and URLPrivate is used otherwise.
+// The size and the MIME type of the content are verified against those of the given file.
+// The number of written bytes is returned.
+//
+// See https://api.slack.com/types/file#authentication
+func (client *Client) DownloadFile(ctx context.Context, file *event.File, w io.Writer, options ...DownloadOption) (int64, error) {
+	fileURL := file.URLPrivateDownload
+	if fileURL == "" {
+		fileURL = file.URLPrivate
+	}
+
+	// Apply settings from the file first so they can be overridden by the given options
+	options = append([]DownloadOption{
+		WithExpectedSize(int64(file.Size)),
+		WithExpectedMIMEType(file.MimeType),
+	}, options...)
+
+	return client.Download(ctx, fileURL, w, options...)
+}
+
+// Download downloads the content of the given private URL with the token and writes it to the given io.Writer.
+// The token is only sent to slack.com and its subdomains over HTTPS. ErrNonSlackHost is returned for any other URL,
+// and the token is removed when the request is redirected to other hosts.
+// Only the given context bounds the duration of the download since it depends on the file size.
+// The number of written bytes is returned.
+func (client *Client) Download(ctx context.Context, fileURL string, w io.Writer, options ...DownloadOption) (int64, error) {
+	opt := &downloadOption{
+		maxSize: DefaultMaxDownloadSize,
+	}
+	for _, o := range options {
+		o(opt)
+	}
+
+	u, err := url.Parse(fileURL)
+	if err != nil {
+		return 0, err
+	}
+	if !isSlackURL(u) {
+		return 0, ErrNonSlackHost
+	}
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+	req = req.WithContext(ctx)
+	token, _, err := client.token(ctx)
+	if err != nil {
+		return 0, err
+	}
+	authorize(req, token)
+
+	resp, err := client.downloadClient().Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, statusErr(resp)
+	}
+
+	// Verify the content before writing anything
+	if opt.maxSize > 0 && resp.ContentLength > opt.maxSize {
+		return 0, ErrFileTooLarge
+	}
+	if opt.size > 0 && resp.ContentLength >= 0 && resp.ContentLength != opt.size {
+		return 0, fmt.Errorf("%w: expected %d bytes but the content length is %d", ErrContentMismatch, opt.size, resp.ContentLength)
+	}
+	if opt.mimeType != "" {
+		mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+		if err != nil || !strings.EqualFold(mediaType, opt.mimeType) {
+			return 0, fmt.Errorf("%w: expected %s but the content type is %s", ErrContentMismatch, opt.mimeType, resp.Header.Get("Content-Type"))
+		}
+	}
+
+	// Read one more byte than the maximum size to detect the excess
+	var body io.Reader = resp.Body
+	if opt.maxSize > 0 {
+		body = io.LimitReader(resp.Body, opt.maxSize+1)
+	}
+	n, err := io.Copy(w, body)
+	if err != nil {
+		return n, err
+	}
+	if opt.maxSize > 0 && n > opt.maxSize {
+		return n, ErrFileTooLarge
+	}
+
+	// Detect the truncated content
+	if resp.ContentLength >= 0 && n != resp.ContentLength {
+		return n, fmt.Errorf("%w: expected %d bytes but %d bytes are read", ErrContentMismatch, resp.ContentLength, n)
+	}
+	if opt.size > 0 && n != opt.size {
+		return n, fmt.Errorf("%w: expected %d bytes but %d bytes are read", ErrContentMismatch, opt.size, n)
+	}
+
+	return n, nil
+}
+
+// downloadClient returns a copy of the underlying http.Client that removes the token when the request is redirected to a non-Slack host.
+func (client *Client) downloadClient() *http.Client {
+	httpClient := *client.httpClient
+	checkRedirect := httpClient.CheckRedirect
+	httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if !isSlackURL(req.URL) {
+			req.Header.Del("Authorization")
+		}
+
+		if checkRedirect != nil {
+			return checkRedirect(req, via)
+		}
+
+		// Same as the default policy of http.Client
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+	return &httpClient
+}
+
+// isSlackURL tells whether the token can be sent to the given URL.
+func isSlackURL(u *url.URL) bool {
+	if u.Scheme != "https" {
+		return false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	return host == "slack.com" || strings.HasSuffix(host, ".slack.com")
+}
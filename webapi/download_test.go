@@ -0,0 +1,165 @@
+package webapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/oklahomer/golack/v2/event"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestClient_DownloadFile(t *testing.T) {
+	newFile := func(path string) *event.File {
+		return &event.File{
+			ID:                 "F123",
+			MimeType:           "text/plain",
+			Size:               11,
+			URLPrivate:         "https://files.slack.com/files-pri/T123-F123/hello.txt",
+			URLPrivateDownload: "https://files.slack.com" + path,
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/download/ok", func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer abc" {
+			t.Errorf("Token is not given: %s.", req.Header.Get("Authorization"))
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write([]byte("hello world"))
+	})
+	mux.HandleFunc("/download/login", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html>hello</html>"))
+	})
+	mux.HandleFunc("/download/slack-redirect", func(w http.ResponseWriter, req *http.Request) {
+		http.Redirect(w, req, "https://files.slack.com/download/ok", http.StatusFound)
+	})
+	mux.HandleFunc("/download/external-redirect", func(w http.ResponseWriter, req *http.Request) {
+		http.Redirect(w, req, "https://cdn.example.com/download/external", http.StatusFound)
+	})
+	mux.HandleFunc("/download/external", func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "" {
+			t.Error("Token is sent to a non-Slack host.")
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("hello world"))
+	})
+	client, server := newFileServerClient(t, mux)
+	defer server.Close()
+
+	t.Run("success", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		n, err := client.DownloadFile(context.TODO(), newFile("/download/ok"), buf)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if n != 11 || buf.String() != "hello world" {
+			t.Errorf("Unexpected content is written: %d, %s.", n, buf.String())
+		}
+	})
+
+	t.Run("redirect to Slack", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		_, err := client.DownloadFile(context.TODO(), newFile("/download/slack-redirect"), buf)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if buf.String() != "hello world" {
+			t.Errorf("Unexpected content is written: %s.", buf.String())
+		}
+	})
+
+	t.Run("redirect to non-Slack host", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		_, err := client.DownloadFile(context.TODO(), newFile("/download/external-redirect"), buf)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if buf.String() != "hello world" {
+			t.Errorf("Unexpected content is written: %s.", buf.String())
+		}
+	})
+
+	t.Run("non-Slack URL", func(t *testing.T) {
+		for _, u := range []string{"https://example.com/file", "http://files.slack.com/file", "https://slack.com.example.com/file"} {
+			_, err := client.Download(context.TODO(), u, &bytes.Buffer{})
+			if err != ErrNonSlackHost {
+				t.Errorf("Expected error is not returned for %s: %#v.", u, err)
+			}
+		}
+	})
+
+	t.Run("unexpected MIME type", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		_, err := client.DownloadFile(context.TODO(), newFile("/download/login"), buf, WithExpectedSize(0))
+		if !errors.Is(err, ErrContentMismatch) {
+			t.Errorf("Expected error is not returned: %#v.", err)
+		}
+
+		if buf.Len() != 0 {
+			t.Errorf("Content is written: %s.", buf.String())
+		}
+	})
+
+	t.Run("unexpected size", func(t *testing.T) {
+		file := newFile("/download/ok")
+		file.Size = 5
+		_, err := client.DownloadFile(context.TODO(), file, &bytes.Buffer{})
+		if !errors.Is(err, ErrContentMismatch) {
+			t.Errorf("Expected error is not returned: %#v.", err)
+		}
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		_, err := client.DownloadFile(context.TODO(), newFile("/download/ok"), &bytes.Buffer{}, WithMaxSize(5))
+		if err != ErrFileTooLarge {
+			t.Errorf("Expected error is not returned: %#v.", err)
+		}
+	})
+
+	t.Run("too large without content length", func(t *testing.T) {
+		mux.HandleFunc("/download/chunked", func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte("hello "))
+			w.(http.Flusher).Flush()
+			w.Write([]byte("world"))
+		})
+
+		buf := &bytes.Buffer{}
+		_, err := client.Download(context.TODO(), "https://files.slack.com/download/chunked", buf, WithMaxSize(5))
+		if err != ErrFileTooLarge {
+			t.Errorf("Expected error is not returned: %#v.", err)
+		}
+
+		if buf.Len() > 6 {
+			t.Errorf("Content is read beyond the limit: %d.", buf.Len())
+		}
+	})
+}
+
+func Test_isSlackURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected bool
+	}{
+		{url: "https://slack.com/api", expected: true},
+		{url: "https://files.slack.com/files-pri/T123-F123/a.png", expected: true},
+		{url: "https://FILES.SLACK.COM/a.png", expected: true},
+		{url: "http://files.slack.com/a.png", expected: false},
+		{url: "https://slack-edge.com/a.png", expected: false},
+		{url: "https://evilslack.com/a.png", expected: false},
+		{url: "https://files.slack.com.evil.example/a.png", expected: false},
+	}
+
+	for _, tt := range tests {
+		u, _ := url.Parse(tt.url)
+		if isSlackURL(u) != tt.expected {
+			t.Errorf("Unexpected result for %s.", tt.url)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package webapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// APIError represents a failed Web API request that is returned with "ok": false.
+// Use errors.As to retrieve this from the returned error and branch with its Code.
+//
+//	var apiErr *webapi.APIError
+//	if errors.As(err, &apiErr) && apiErr.Code == "not_in_channel" {
+//	    // Join the channel and try again
+//	}
+//
+// See https://api.slack.com/web#evaluating_responses
+type APIError struct {
+	// Method is the name of the requested Web API method such as chat.postMessage.
+	Method string
+
+	// Code is the error code such as channel_not_found, not_in_channel and ratelimited.
+	Code string
+
+	Warning string
+
+	// Messages and Warnings are the supplemental descriptions given in response_metadata.
+	Messages []string
+	Warnings []string
+
+	// Needed and Provided are the scopes given with missing_scope error.
+	Needed   string
+	Provided string
+}
+
+var _ error = (*APIError)(nil)
+
+// NewAPIError creates new APIError with given method and response.
+func NewAPIError(slackMethod string, response *APIResponse) *APIError {
+	e := &APIError{
+		Method:   slackMethod,
+		Code:     response.Error,
+		Warning:  response.Warning,
+		Needed:   response.Needed,
+		Provided: response.Provided,
+	}
+
+	if response.ResponseMetadata != nil {
+		e.Messages = response.ResponseMetadata.Messages
+		e.Warnings = response.ResponseMetadata.Warnings
+	}
+
+	return e
+}
+
+// Error returns its error string.
+func (e *APIError) Error() string {
+	str := fmt.Sprintf("failed %s request: %s", e.Method, e.Code)
+
+	if e.Needed != "" || e.Provided != "" {
+		str += fmt.Sprintf(" (needed: %s, provided: %s)", e.Needed, e.Provided)
+	}
+
+	if len(e.Messages) > 0 {
+		str += fmt.Sprintf(": %s", strings.Join(e.Messages, ", "))
+	}
+
+	return str
+}
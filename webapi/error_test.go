@@ -0,0 +1,73 @@
+package webapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestAPIResponse_Err(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		response := &APIResponse{OK: true}
+		if err := response.Err("chat.postMessage"); err != nil {
+			t.Errorf("Unexpected error is returned: %#v.", err)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		str := `{
+			"ok": false,
+			"error": "missing_scope",
+			"warning": "missing_charset",
+			"needed": "chat:write",
+			"provided": "channels:read",
+			"response_metadata": {
+				"messages": ["[ERROR] missing required scope"],
+				"warnings": ["missing_charset"]
+			}
+		}`
+		response := &APIResponse{}
+		err := json.Unmarshal([]byte(str), response)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		err = fmt.Errorf("wrapped: %w", response.Err("chat.postMessage"))
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+
+		expected := &APIError{
+			Method:   "chat.postMessage",
+			Code:     "missing_scope",
+			Warning:  "missing_charset",
+			Messages: []string{"[ERROR] missing required scope"},
+			Warnings: []string{"missing_charset"},
+			Needed:   "chat:write",
+			Provided: "channels:read",
+		}
+		if fmt.Sprintf("%#v", apiErr) != fmt.Sprintf("%#v", expected) {
+			t.Errorf("Unexpected error is returned: %#v.", apiErr)
+		}
+	})
+}
+
+func TestAPIError_Error(t *testing.T) {
+	err := &APIError{
+		Method:   "chat.postMessage",
+		Code:     "missing_scope",
+		Messages: []string{"[ERROR] missing required scope"},
+		Needed:   "chat:write",
+		Provided: "channels:read",
+	}
+
+	str := err.Error()
+	for _, expected := range []string{"chat.postMessage", "missing_scope", "chat:write", "channels:read", "[ERROR] missing required scope"} {
+		if !strings.Contains(str, expected) {
+			t.Errorf("Error string does not contain %s: %s.", expected, str)
+		}
+	}
+}
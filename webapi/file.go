@@ -0,0 +1,386 @@
+package webapi
+
+import (
+	"context"
+	"errors"
+	"github.com/oklahomer/golack/v2/event"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrUnknownFileSize is returned when the size of the file content is required but cannot be determined.
+	// Set FileUpload.Size to upload content from an arbitrary io.Reader with UploadFileExternal.
+	ErrUnknownFileSize = errors.New("size of the file content is unknown")
+
+	// ErrFileNotRewindable is returned when the file content must be sent again but the reader is not an io.Seeker.
+	ErrFileNotRewindable = errors.New("file content cannot be read again")
+)
+
+// ProgressFunc is called each time a chunk of the file content is sent.
+// total is the size of the file content, or -1 when the size is unknown.
+type ProgressFunc func(sent int64, total int64)
+
+// FileUpload is a payload to upload a file.
+// Pass this to Client.Post with files.upload method to upload with multipart/form-data,
+// or to Client.UploadFileExternal to upload with files.getUploadURLExternal and files.completeUploadExternal methods.
+// The content is streamed from Reader without being buffered in memory.
+type FileUpload struct {
+	Reader          io.Reader
+	FileName        string
+	FileType        string
+	Title           string
+	InitialComment  string
+	ChannelIDs      []event.ChannelID
+	ThreadTimeStamp string
+	AltText         string
+
+	// Size is the size of the content in bytes.
+	// This is detected from Reader when Reader is *bytes.Reader, *strings.Reader, *bytes.Buffer or *os.File.
+	Size int64
+
+	// Progress is called as the content is sent.
+	Progress ProgressFunc
+
+	read bool
+}
+
+// WithChannels sets the channels to share the uploaded file.
+// See https://api.slack.com/methods/files.upload
+func (upload *FileUpload) WithChannels(channelIDs ...event.ChannelID) *FileUpload {
+	upload.ChannelIDs = channelIDs
+	return upload
+}
+
+// WithThreadTimeStamp sets the timestamp of the parent message to share the uploaded file in the thread.
+// See https://api.slack.com/methods/files.upload
+func (upload *FileUpload) WithThreadTimeStamp(ts string) *FileUpload {
+	upload.ThreadTimeStamp = ts
+	return upload
+}
+
+// WithTitle sets the title of the file.
+func (upload *FileUpload) WithTitle(title string) *FileUpload {
+	upload.Title = title
+	return upload
+}
+
+// WithInitialComment sets the message text introducing the file in the shared channels.
+func (upload *FileUpload) WithInitialComment(comment string) *FileUpload {
+	upload.InitialComment = comment
+	return upload
+}
+
+// WithFileType sets the file type such as "csv" and "png".
+// See https://api.slack.com/types/file#file_types
+func (upload *FileUpload) WithFileType(fileType string) *FileUpload {
+	upload.FileType = fileType
+	return upload
+}
+
+// WithAltText sets the description of the image for screen-readers.
+// This is only supported by UploadFileExternal.
+func (upload *FileUpload) WithAltText(altText string) *FileUpload {
+	upload.AltText = altText
+	return upload
+}
+
+// WithSize sets the size of the content in bytes.
+func (upload *FileUpload) WithSize(size int64) *FileUpload {
+	upload.Size = size
+	return upload
+}
+
+// WithProgress sets the function to be called as the content is sent.
+func (upload *FileUpload) WithProgress(progress ProgressFunc) *FileUpload {
+	upload.Progress = progress
+	return upload
+}
+
+// NewFileUpload creates FileUpload instance to upload the content read from the given reader with the given file name.
+func NewFileUpload(fileName string, reader io.Reader) *FileUpload {
+	return &FileUpload{
+		Reader:   reader,
+		FileName: fileName,
+	}
+}
+
+// size returns the size of the content, or -1 when the size cannot be determined.
+func (upload *FileUpload) size() int64 {
+	if upload.Size > 0 {
+		return upload.Size
+	}
+
+	switch typed := upload.Reader.(type) {
+	case interface{ Len() int }:
+		// *bytes.Reader, *strings.Reader and *bytes.Buffer
+		return int64(typed.Len())
+
+	case *os.File:
+		stat, err := typed.Stat()
+		if err != nil {
+			return -1
+		}
+		return stat.Size()
+
+	default:
+		return -1
+
+	}
+}
+
+// content returns the reader of the content that reports the progress.
+// Since a request may be sent again on HTTP 429, the reader is rewound when it is read for the second time.
+func (upload *FileUpload) content(total int64) (io.Reader, error) {
+	if upload.read {
+		seeker, ok := upload.Reader.(io.Seeker)
+		if !ok {
+			return nil, ErrFileNotRewindable
+		}
+		_, err := seeker.Seek(0, io.SeekStart)
+		if err != nil {
+			return nil, err
+		}
+	}
+	upload.read = true
+
+	if upload.Progress == nil {
+		return upload.Reader, nil
+	}
+	return &progressReader{reader: upload.Reader, total: total, progress: upload.Progress}, nil
+}
+
+// writeMultipart writes the fields and the content for files.upload method.
+func (upload *FileUpload) writeMultipart(w *multipart.Writer, content io.Reader) error {
+	fields := url.Values{}
+	fields.Set("filename", upload.FileName)
+	if len(upload.ChannelIDs) > 0 {
+		fields.Set("channels", joinChannelIDs(upload.ChannelIDs))
+	}
+	if upload.FileType != "" {
+		fields.Set("filetype", upload.FileType)
+	}
+	if upload.InitialComment != "" {
+		fields.Set("initial_comment", upload.InitialComment)
+	}
+	if upload.ThreadTimeStamp != "" {
+		fields.Set("thread_ts", upload.ThreadTimeStamp)
+	}
+	if upload.Title != "" {
+		fields.Set("title", upload.Title)
+	}
+
+	for key := range fields {
+		err := w.WriteField(key, fields.Get(key))
+		if err != nil {
+			return err
+		}
+	}
+
+	part, err := w.CreateFormFile("file", upload.FileName)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(part, content)
+	return err
+}
+
+type progressReader struct {
+	reader   io.Reader
+	sent     int64
+	total    int64
+	progress ProgressFunc
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.sent += int64(n)
+		r.progress(r.sent, r.total)
+	}
+	return n, err
+}
+
+// postMultipart sends FileUpload as multipart/form-data.
+// The content is streamed through a pipe so the whole file is not loaded into memory.
+func (client *Client) postMultipart(ctx context.Context, slackMethod string, upload *FileUpload, response interface{}) error {
+	endpoint := buildEndpoint(slackMethod, nil)
+	newRequest := func() (*http.Request, error) {
+		content, err := upload.content(upload.size())
+		if err != nil {
+			return nil, err
+		}
+
+		reader, writer := io.Pipe()
+		mw := multipart.NewWriter(writer)
+		go func() {
+			err := upload.writeMultipart(mw, content)
+			if err == nil {
+				err = mw.Close()
+			}
+			// The error is passed to the reader side, which is http.Client
+			//noinspection ALL
+			writer.CloseWithError(err)
+		}()
+
+		req, err := http.NewRequest(http.MethodPost, endpoint.String(), reader)
+		if err != nil {
+			//noinspection ALL
+			reader.Close()
+			return nil, err
+		}
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		return req, nil
+	}
+
+	return client.do(ctx, slackMethod, "", newRequest, response)
+}
+
+// FileResponse is a response of methods that return a single file such as files.upload.
+type FileResponse struct {
+	APIResponse
+	File *event.File `json:"file"`
+}
+
+// FilesResponse is a response of files.completeUploadExternal method.
+type FilesResponse struct {
+	APIResponse
+	Files []*event.File `json:"files"`
+}
+
+// UploadURLExternal is a response of files.getUploadURLExternal method.
+// See https://api.slack.com/methods/files.getUploadURLExternal
+type UploadURLExternal struct {
+	APIResponse
+	UploadURL string       `json:"upload_url"`
+	FileID    event.FileID `json:"file_id"`
+}
+
+// CompleteUploadExternal is a payload to be sent with files.completeUploadExternal method.
+// See https://api.slack.com/methods/files.completeUploadExternal
+type CompleteUploadExternal struct {
+	Files           []*CompleteUploadExternalFile `json:"files"`
+	ChannelID       event.ChannelID               `json:"channel_id,omitempty"`
+	Channels        string                        `json:"channels,omitempty"`
+	InitialComment  string                        `json:"initial_comment,omitempty"`
+	ThreadTimeStamp string                        `json:"thread_ts,omitempty"`
+}
+
+// CompleteUploadExternalFile describes an uploaded file to be completed.
+type CompleteUploadExternalFile struct {
+	ID    event.FileID `json:"id"`
+	Title string       `json:"title,omitempty"`
+}
+
+// UploadFileExternal uploads a file with the flow that replaces files.upload method.
+// This first calls files.getUploadURLExternal to retrieve the upload URL, then sends the content to the URL,
+// and finally calls files.completeUploadExternal to share the file with the given channels or thread.
+// Since the size of the content must be declared beforehand, ErrUnknownFileSize is returned when the size is neither given nor detectable.
+//
+// See https://api.slack.com/messaging/files#uploading_files
+func (client *Client) UploadFileExternal(ctx context.Context, upload *FileUpload) (*FilesResponse, error) {
+	size := upload.size()
+	if size < 0 {
+		return nil, ErrUnknownFileSize
+	}
+
+	// Retrieve the URL to upload the content
+	params := url.Values{}
+	params.Set("filename", upload.FileName)
+	params.Set("length", strconv.FormatInt(size, 10))
+	if upload.AltText != "" {
+		params.Set("alt_txt", upload.AltText)
+	}
+	uploadURL := &UploadURLExternal{}
+	err := client.Post(ctx, "files.getUploadURLExternal", params, uploadURL)
+	if err != nil {
+		return nil, err
+	}
+	err = uploadURL.Err("files.getUploadURLExternal")
+	if err != nil {
+		return nil, err
+	}
+
+	// Send the content
+	err = client.sendContent(ctx, uploadURL.UploadURL, upload, size)
+	if err != nil {
+		return nil, err
+	}
+
+	// Complete the upload and share the file
+	complete := &CompleteUploadExternal{
+		Files: []*CompleteUploadExternalFile{
+			{
+				ID:    uploadURL.FileID,
+				Title: upload.Title,
+			},
+		},
+		InitialComment:  upload.InitialComment,
+		ThreadTimeStamp: upload.ThreadTimeStamp,
+	}
+	if len(upload.ChannelIDs) == 1 {
+		complete.ChannelID = upload.ChannelIDs[0]
+	} else if len(upload.ChannelIDs) > 1 {
+		complete.Channels = joinChannelIDs(upload.ChannelIDs)
+	}
+	response := &FilesResponse{}
+	err = client.Post(ctx, "files.completeUploadExternal", complete, response)
+	if err != nil {
+		return nil, err
+	}
+	err = response.Err("files.completeUploadExternal")
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// sendContent sends the content to the URL given by files.getUploadURLExternal.
+// The URL is pre-signed, so the token is not sent. The timeout for files.upload method is applied.
+func (client *Client) sendContent(ctx context.Context, uploadURL string, upload *FileUpload, size int64) error {
+	content, err := upload.content(size)
+	if err != nil {
+		return err
+	}
+
+	if timeout := client.config.timeout("files.upload"); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequest(http.MethodPost, uploadURL, ioutil.NopCloser(content))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return statusErr(resp)
+	}
+
+	return nil
+}
+
+func joinChannelIDs(channelIDs []event.ChannelID) string {
+	ids := make([]string, len(channelIDs))
+	for i, id := range channelIDs {
+		ids[i] = id.String()
+	}
+	return strings.Join(ids, ",")
+}
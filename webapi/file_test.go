@@ -0,0 +1,273 @@
+package webapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func newFileServerClient(t *testing.T, mux *http.ServeMux, options ...ClientOption) (*Client, *httptest.Server) {
+	server := httptest.NewServer(mux)
+	target, _ := url.Parse(server.URL)
+	options = append(options, WithHTTPClient(&http.Client{Transport: &rewriteRoundTripper{target: target}}))
+	client := NewClient(&Config{Token: "abc", RequestTimeout: 3 * time.Second}, options...)
+	return client, server
+}
+
+func TestFileUpload_size(t *testing.T) {
+	file, err := ioutil.TempFile("", "golack")
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+	file.Write([]byte("hello"))
+
+	tests := []struct {
+		upload   *FileUpload
+		expected int64
+	}{
+		{upload: NewFileUpload("a.txt", strings.NewReader("hello")), expected: 5},
+		{upload: NewFileUpload("a.txt", bytes.NewReader([]byte("hello"))), expected: 5},
+		{upload: NewFileUpload("a.txt", bytes.NewBufferString("hello")), expected: 5},
+		{upload: NewFileUpload("a.txt", file), expected: 5},
+		{upload: NewFileUpload("a.txt", iotest.OneByteReader(strings.NewReader("hello"))), expected: -1},
+		{upload: NewFileUpload("a.txt", iotest.OneByteReader(strings.NewReader("hello"))).WithSize(5), expected: 5},
+	}
+
+	for i, tt := range tests {
+		if size := tt.upload.size(); size != tt.expected {
+			t.Errorf("Unexpected size is returned on test #%d: %d.", i+1, size)
+		}
+	}
+}
+
+func TestClient_Post_FileUpload(t *testing.T) {
+	t.Run("multipart", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/files.upload", func(w http.ResponseWriter, req *http.Request) {
+			if req.Header.Get("Authorization") != "Bearer abc" {
+				t.Errorf("Token is not given: %s.", req.Header.Get("Authorization"))
+			}
+
+			err := req.ParseMultipartForm(1024)
+			if err != nil {
+				t.Errorf("Unexpected error is returned: %s.", err.Error())
+				return
+			}
+
+			if req.FormValue("channels") != "C1,C2" || req.FormValue("thread_ts") != "1.0" || req.FormValue("title") != "Report" {
+				t.Errorf("Expected fields are not given: %#v.", req.MultipartForm.Value)
+			}
+
+			file, header, err := req.FormFile("file")
+			if err != nil {
+				t.Errorf("File is not given: %s.", err.Error())
+				return
+			}
+			defer file.Close()
+			b, _ := ioutil.ReadAll(file)
+			if header.Filename != "report.csv" || string(b) != "a,b,c" {
+				t.Errorf("Unexpected file is given: %s, %s.", header.Filename, string(b))
+			}
+
+			w.Write([]byte(`{"ok": true, "file": {"id": "F123", "name": "report.csv"}}`))
+		})
+		client, server := newFileServerClient(t, mux)
+		defer server.Close()
+
+		var progress []int64
+		upload := NewFileUpload("report.csv", strings.NewReader("a,b,c")).
+			WithChannels("C1", "C2").
+			WithThreadTimeStamp("1.0").
+			WithTitle("Report").
+			WithProgress(func(sent int64, total int64) {
+				if total != 5 {
+					t.Errorf("Unexpected total is given: %d.", total)
+				}
+				progress = append(progress, sent)
+			})
+
+		response := &FileResponse{}
+		err := client.Post(context.TODO(), "files.upload", upload, response)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if response.File.ID != "F123" {
+			t.Errorf("Response is not decoded: %#v.", response.File)
+		}
+
+		if len(progress) == 0 || progress[len(progress)-1] != 5 {
+			t.Errorf("Progress is not reported: %#v.", progress)
+		}
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		mutex := &sync.Mutex{}
+		var contents []string
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/files.upload", func(w http.ResponseWriter, req *http.Request) {
+			file, _, err := req.FormFile("file")
+			if err != nil {
+				t.Errorf("File is not given: %s.", err.Error())
+				return
+			}
+			b, _ := ioutil.ReadAll(file)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			contents = append(contents, string(b))
+			if len(contents) == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.Write([]byte(`{"ok": true}`))
+		})
+		client, server := newFileServerClient(t, mux, WithRateLimitRetry(1))
+		defer server.Close()
+
+		// The content is rewound for the second attempt
+		err := client.Post(context.TODO(), "files.upload", NewFileUpload("a.txt", strings.NewReader("hello")), &FileResponse{})
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+		if len(contents) != 2 || contents[1] != "hello" {
+			t.Errorf("Content is not sent again: %#v.", contents)
+		}
+
+		// The content cannot be sent again
+		contents = nil
+		upload := NewFileUpload("a.txt", iotest.OneByteReader(strings.NewReader("hello")))
+		err = client.Post(context.TODO(), "files.upload", upload, &FileResponse{})
+		if err != ErrFileNotRewindable {
+			t.Errorf("Expected error is not returned: %#v.", err)
+		}
+	})
+}
+
+func TestClient_UploadFileExternal(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var serverURL string
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/files.getUploadURLExternal", func(w http.ResponseWriter, req *http.Request) {
+			req.ParseForm()
+			if req.FormValue("filename") != "hello.txt" || req.FormValue("length") != "11" {
+				t.Errorf("Expected values are not given: %#v.", req.Form)
+			}
+			fmt.Fprintf(w, `{"ok": true, "upload_url": "%s/upload/v1/abc", "file_id": "F123"}`, serverURL)
+		})
+		mux.HandleFunc("/upload/v1/abc", func(w http.ResponseWriter, req *http.Request) {
+			if req.Header.Get("Authorization") != "" {
+				t.Error("Token is sent to the upload URL.")
+			}
+			if req.ContentLength != 11 {
+				t.Errorf("Unexpected content length is given: %d.", req.ContentLength)
+			}
+			b, _ := ioutil.ReadAll(req.Body)
+			if string(b) != "hello world" {
+				t.Errorf("Unexpected content is given: %s.", string(b))
+			}
+			w.Write([]byte("OK - 11"))
+		})
+		mux.HandleFunc("/api/files.completeUploadExternal", func(w http.ResponseWriter, req *http.Request) {
+			complete := &CompleteUploadExternal{}
+			err := json.NewDecoder(req.Body).Decode(complete)
+			if err != nil {
+				t.Errorf("Unexpected error is returned: %s.", err.Error())
+				return
+			}
+			if len(complete.Files) != 1 || complete.Files[0].ID != "F123" || complete.Files[0].Title != "Greeting" {
+				t.Errorf("Expected file is not given: %#v.", complete.Files)
+			}
+			if complete.ChannelID != "C123" || complete.ThreadTimeStamp != "1.0" || complete.InitialComment != "Hi" {
+				t.Errorf("Expected destination is not given: %#v.", complete)
+			}
+			w.Write([]byte(`{"ok": true, "files": [{"id": "F123", "title": "Greeting"}]}`))
+		})
+		client, server := newFileServerClient(t, mux)
+		defer server.Close()
+		serverURL = server.URL
+
+		var sent int64
+		upload := NewFileUpload("hello.txt", strings.NewReader("hello world")).
+			WithChannels("C123").
+			WithThreadTimeStamp("1.0").
+			WithTitle("Greeting").
+			WithInitialComment("Hi").
+			WithProgress(func(s int64, _ int64) {
+				sent = s
+			})
+
+		response, err := client.UploadFileExternal(context.TODO(), upload)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if len(response.Files) != 1 || response.Files[0].ID != "F123" {
+			t.Errorf("Response is not decoded: %#v.", response.Files)
+		}
+
+		if sent != 11 {
+			t.Errorf("Progress is not reported: %d.", sent)
+		}
+	})
+
+	t.Run("upload failure", func(t *testing.T) {
+		var serverURL string
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/files.getUploadURLExternal", func(w http.ResponseWriter, _ *http.Request) {
+			fmt.Fprintf(w, `{"ok": true, "upload_url": "%s/upload/v1/abc", "file_id": "F123"}`, serverURL)
+		})
+		mux.HandleFunc("/upload/v1/abc", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+		mux.HandleFunc("/api/files.completeUploadExternal", func(w http.ResponseWriter, _ *http.Request) {
+			t.Error("Upload is completed on failure.")
+		})
+		client, server := newFileServerClient(t, mux)
+		defer server.Close()
+		serverURL = server.URL
+
+		_, err := client.UploadFileExternal(context.TODO(), NewFileUpload("hello.txt", strings.NewReader("hello")))
+		if statusErr, ok := err.(*StatusError); !ok || statusErr.StatusCode != http.StatusInternalServerError {
+			t.Errorf("Expected error is not returned: %#v.", err)
+		}
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/files.getUploadURLExternal", func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte(`{"ok": false, "error": "invalid_arguments"}`))
+		})
+		client, server := newFileServerClient(t, mux)
+		defer server.Close()
+
+		_, err := client.UploadFileExternal(context.TODO(), NewFileUpload("hello.txt", strings.NewReader("hello")))
+		if apiErr, ok := err.(*APIError); !ok || apiErr.Code != "invalid_arguments" {
+			t.Errorf("Expected error is not returned: %#v.", err)
+		}
+	})
+
+	t.Run("unknown size", func(t *testing.T) {
+		client := NewClient(&Config{})
+		upload := NewFileUpload("hello.txt", iotest.OneByteReader(strings.NewReader("hello")))
+
+		_, err := client.UploadFileExternal(context.TODO(), upload)
+		if err != ErrUnknownFileSize {
+			t.Errorf("Expected error is not returned: %#v.", err)
+		}
+	})
+}
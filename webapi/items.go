@@ -0,0 +1,208 @@
+package webapi
+
+import (
+	"github.com/oklahomer/golack/v2/event"
+	"net/url"
+	"strconv"
+)
+
+// ItemRef identifies an item to react, pin or star.
+// A message is identified by ChannelID and TimeStamp, while a file is identified by FileID and a file comment by FileCommentID.
+type ItemRef struct {
+	ChannelID     event.ChannelID  `json:"channel,omitempty"`
+	TimeStamp     *event.TimeStamp `json:"timestamp,omitempty"`
+	FileID        event.FileID     `json:"file,omitempty"`
+	FileCommentID event.CommentID  `json:"file_comment,omitempty"`
+}
+
+var _ URLValuer = (*ItemRef)(nil)
+
+// NewMessageItem creates ItemRef that points to the message with the given channel and timestamp.
+// Pass the ChannelID and TimeStamp of an incoming message to react to the message.
+func NewMessageItem(channelID event.ChannelID, ts *event.TimeStamp) *ItemRef {
+	return &ItemRef{
+		ChannelID: channelID,
+		TimeStamp: ts,
+	}
+}
+
+// NewFileItem creates ItemRef that points to the given file.
+func NewFileItem(fileID event.FileID) *ItemRef {
+	return &ItemRef{
+		FileID: fileID,
+	}
+}
+
+// NewItemRef creates ItemRef that points to the given item such as the one given by reaction_added, pin_added and star_added events.
+func NewItemRef(item *event.Item) *ItemRef {
+	ref := &ItemRef{
+		ChannelID: item.ChannelID,
+		TimeStamp: item.TimeStamp,
+	}
+	if item.File != nil {
+		ref.FileID = item.File.ID
+	}
+	if item.Comment != nil {
+		ref.FileCommentID = item.Comment.ID
+	}
+	return ref
+}
+
+// ToURLValues returns query parameters that identify the item.
+func (item *ItemRef) ToURLValues() url.Values {
+	values := url.Values{}
+	if item.ChannelID != "" {
+		values.Set("channel", item.ChannelID.String())
+	}
+	if item.TimeStamp != nil {
+		values.Set("timestamp", item.TimeStamp.String())
+	}
+	if item.FileID != "" {
+		values.Set("file", string(item.FileID))
+	}
+	if item.FileCommentID != "" {
+		values.Set("file_comment", string(item.FileCommentID))
+	}
+	return values
+}
+
+// Reaction represents an emoji reaction attached to an item.
+type Reaction struct {
+	Name  string         `json:"name"`
+	Count int            `json:"count"`
+	Users []event.UserID `json:"users"`
+}
+
+// ListedItem is an item returned by reactions.get, reactions.list and pins.list methods.
+// Reactions are given with the Message, File or Comment depending on the Type.
+type ListedItem struct {
+	Type      string               `json:"type"`
+	ChannelID event.ChannelID      `json:"channel"`
+	Message   *ConversationMessage `json:"message"`
+	File      *event.File          `json:"file"`
+	Comment   *event.Comment       `json:"comment"`
+
+	// Created and CreatedBy are given by pins.list.
+	Created   *event.TimeStamp `json:"created"`
+	CreatedBy event.UserID     `json:"created_by"`
+}
+
+// ItemResponse is a response of reactions.get method.
+// See https://api.slack.com/methods/reactions.get
+type ItemResponse struct {
+	APIResponse
+	ListedItem
+}
+
+// ItemsResponse is a response of reactions.list and pins.list methods.
+type ItemsResponse struct {
+	APIResponse
+	Items []*ListedItem `json:"items"`
+}
+
+// AddReaction is a payload to be sent with reactions.add method.
+// See https://api.slack.com/methods/reactions.add
+type AddReaction struct {
+	*ItemRef
+	Name string `json:"name"`
+}
+
+var _ URLValuer = (*AddReaction)(nil)
+
+// NewAddReaction creates AddReaction instance to add the reaction with the given emoji name such as "eyes" to the given item.
+func NewAddReaction(item *ItemRef, name string) *AddReaction {
+	return &AddReaction{
+		ItemRef: item,
+		Name:    name,
+	}
+}
+
+// ToURLValues returns form values that identify the item along with the emoji name.
+// Because the embedded ItemRef also implements URLValuer, this must be defined to send the name.
+func (reaction *AddReaction) ToURLValues() url.Values {
+	values := reaction.ItemRef.ToURLValues()
+	values.Set("name", reaction.Name)
+	return values
+}
+
+// RemoveReaction is a payload to be sent with reactions.remove method.
+// See https://api.slack.com/methods/reactions.remove
+type RemoveReaction struct {
+	*ItemRef
+	Name string `json:"name"`
+}
+
+var _ URLValuer = (*RemoveReaction)(nil)
+
+// NewRemoveReaction creates RemoveReaction instance to remove the reaction with the given emoji name from the given item.
+func NewRemoveReaction(item *ItemRef, name string) *RemoveReaction {
+	return &RemoveReaction{
+		ItemRef: item,
+		Name:    name,
+	}
+}
+
+// ToURLValues returns form values that identify the item along with the emoji name.
+func (reaction *RemoveReaction) ToURLValues() url.Values {
+	values := reaction.ItemRef.ToURLValues()
+	values.Set("name", reaction.Name)
+	return values
+}
+
+// ReactionsListRequest is a payload to be sent with reactions.list method.
+// See https://api.slack.com/methods/reactions.list
+type ReactionsListRequest struct {
+	UserID event.UserID
+	Cursor string
+	Full   bool
+	Limit  int
+}
+
+var _ URLValuer = (*ReactionsListRequest)(nil)
+
+// NewReactionsListRequest creates ReactionsListRequest to list the items reacted by the authenticated user.
+func NewReactionsListRequest() *ReactionsListRequest {
+	return &ReactionsListRequest{}
+}
+
+// WithUserID sets the user whose reactions are listed.
+func (req *ReactionsListRequest) WithUserID(userID event.UserID) *ReactionsListRequest {
+	req.UserID = userID
+	return req
+}
+
+// WithCursor sets the cursor given by the previous response to fetch the next page.
+func (req *ReactionsListRequest) WithCursor(cursor string) *ReactionsListRequest {
+	req.Cursor = cursor
+	return req
+}
+
+// WithFull sets optional boolean value to return the complete reaction list.
+func (req *ReactionsListRequest) WithFull(flg bool) *ReactionsListRequest {
+	req.Full = flg
+	return req
+}
+
+// WithLimit sets the maximum number of items to return.
+func (req *ReactionsListRequest) WithLimit(limit int) *ReactionsListRequest {
+	req.Limit = limit
+	return req
+}
+
+// ToURLValues returns query parameters of the request.
+func (req *ReactionsListRequest) ToURLValues() url.Values {
+	values := url.Values{}
+	if req.UserID != "" {
+		values.Set("user", req.UserID.String())
+	}
+	if req.Cursor != "" {
+		values.Set("cursor", req.Cursor)
+	}
+	if req.Full {
+		values.Set("full", "true")
+	}
+	if req.Limit > 0 {
+		values.Set("limit", strconv.Itoa(req.Limit))
+	}
+	return values
+}
@@ -0,0 +1,102 @@
+package webapi
+
+import (
+	"encoding/json"
+	"github.com/oklahomer/golack/v2/event"
+	"testing"
+)
+
+func TestNewItemRef(t *testing.T) {
+	input := []byte(`{"type": "message", "channel": "C123", "ts": "1360782400.498405"}`)
+	item := &event.Item{}
+	err := json.Unmarshal(input, item)
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+
+	ref := NewItemRef(item)
+	values := ref.ToURLValues()
+	if values.Get("channel") != "C123" || values.Get("timestamp") != "1360782400.498405" {
+		t.Errorf("Expected values are not set: %s.", values.Encode())
+	}
+
+	item = &event.Item{Type: "file", File: &event.File{ID: "F123"}}
+	ref = NewItemRef(item)
+	if ref.FileID != "F123" || ref.TimeStamp != nil {
+		t.Errorf("Unexpected item is built: %#v.", ref)
+	}
+}
+
+func TestNewAddReaction(t *testing.T) {
+	ts := &event.TimeStamp{OriginalValue: "1360782400.498405"}
+	reaction := NewAddReaction(NewMessageItem("C123", ts), "eyes")
+
+	p, err := genPayload("reactions.add", reaction)
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+
+	if p.Type != "application/x-www-form-urlencoded" {
+		t.Errorf("Unexpected content type is set: %s.", p.Type)
+	}
+
+	expected := "channel=C123&name=eyes&timestamp=1360782400.498405"
+	if string(p.Body) != expected {
+		t.Errorf("Unexpected payload is built: %s.", string(p.Body))
+	}
+}
+
+func TestNewRemoveReaction(t *testing.T) {
+	reaction := NewRemoveReaction(NewFileItem("F123"), "eyes")
+
+	p, err := genPayload("reactions.remove", reaction)
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+
+	if p.Type != "application/x-www-form-urlencoded" {
+		t.Errorf("Unexpected content type is set: %s.", p.Type)
+	}
+
+	expected := "file=F123&name=eyes"
+	if string(p.Body) != expected {
+		t.Errorf("Unexpected payload is built: %s.", string(p.Body))
+	}
+}
+
+func TestReactionsListRequest_ToURLValues(t *testing.T) {
+	values := NewReactionsListRequest().WithUserID("U123").WithCursor("abc").WithFull(true).WithLimit(10).ToURLValues()
+
+	if values.Get("user") != "U123" || values.Get("cursor") != "abc" || values.Get("full") != "true" || values.Get("limit") != "10" {
+		t.Errorf("Expected values are not set: %s.", values.Encode())
+	}
+}
+
+func TestItemResponse_UnmarshalJSON(t *testing.T) {
+	input := []byte(`{
+		"ok": true,
+		"type": "message",
+		"channel": "C123",
+		"message": {
+			"type": "message",
+			"text": "Hello",
+			"ts": "1360782400.498405",
+			"reactions": [{"name": "eyes", "count": 2, "users": ["U1", "U2"]}]
+		}
+	}`)
+
+	response := &ItemResponse{}
+	err := json.Unmarshal(input, response)
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+
+	if !response.OK || response.ChannelID != "C123" {
+		t.Errorf("Response is not decoded: %#v.", response)
+	}
+
+	reactions := response.Message.Reactions
+	if len(reactions) != 1 || reactions[0].Name != "eyes" || reactions[0].Count != 2 || reactions[0].Users[1] != "U2" {
+		t.Errorf("Reactions are not decoded: %#v.", reactions)
+	}
+}
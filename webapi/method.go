@@ -37,6 +37,9 @@ var (
 		"admin.users.setRegular",
 		"api.test",
 		"auth.test",
+		"bookmarks.add",
+		"bookmarks.edit",
+		"bookmarks.remove",
 		"calls.add",
 		"calls.end",
 		"calls.info",
@@ -80,6 +83,7 @@ var (
 		"dnd.endDnd",
 		"dnd.endSnooze",
 		"files.comments.delete",
+		"files.completeUploadExternal",
 		"files.delete",
 		"files.revokePublicURL",
 		"files.sharedPublicURL",
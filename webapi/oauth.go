@@ -0,0 +1,119 @@
+package webapi
+
+import (
+	"github.com/oklahomer/golack/v2/event"
+	"net/url"
+)
+
+// OAuthV2Access is a payload to be sent with oauth.v2.access method.
+// This is sent as application/x-www-form-urlencoded since the method does not accept JSON payload.
+// See https://api.slack.com/methods/oauth.v2.access
+type OAuthV2Access struct {
+	ClientID     string
+	ClientSecret string
+	Code         string
+	RedirectURI  string
+
+	// GrantType and RefreshToken are given to refresh a token when token rotation is enabled.
+	GrantType    string
+	RefreshToken string
+}
+
+var _ URLValuer = (*OAuthV2Access)(nil)
+
+// NewOAuthV2Access creates OAuthV2Access to exchange the given temporary authorization code for access tokens.
+func NewOAuthV2Access(clientID string, clientSecret string, code string) *OAuthV2Access {
+	return &OAuthV2Access{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Code:         code,
+	}
+}
+
+// NewOAuthV2Refresh creates OAuthV2Access to exchange the given refresh token for a new access token.
+// See https://api.slack.com/authentication/rotation
+func NewOAuthV2Refresh(clientID string, clientSecret string, refreshToken string) *OAuthV2Access {
+	return &OAuthV2Access{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		GrantType:    "refresh_token",
+		RefreshToken: refreshToken,
+	}
+}
+
+// WithRedirectURI sets the redirect_uri that was given to the authorization request.
+// This must be the same value when it was given to the authorization request.
+func (req *OAuthV2Access) WithRedirectURI(uri string) *OAuthV2Access {
+	req.RedirectURI = uri
+	return req
+}
+
+// ToURLValues returns form values of the request.
+func (req *OAuthV2Access) ToURLValues() url.Values {
+	values := url.Values{}
+	values.Set("client_id", req.ClientID)
+	values.Set("client_secret", req.ClientSecret)
+	if req.Code != "" {
+		values.Set("code", req.Code)
+	}
+	if req.RedirectURI != "" {
+		values.Set("redirect_uri", req.RedirectURI)
+	}
+	if req.GrantType != "" {
+		values.Set("grant_type", req.GrantType)
+	}
+	if req.RefreshToken != "" {
+		values.Set("refresh_token", req.RefreshToken)
+	}
+	return values
+}
+
+// OAuthV2AccessResponse is a response of oauth.v2.access method.
+// AccessToken is the bot token while AuthedUser.AccessToken is the user token given when user scopes are requested.
+// See https://api.slack.com/methods/oauth.v2.access
+type OAuthV2AccessResponse struct {
+	APIResponse
+	AppID               event.AppID      `json:"app_id"`
+	AccessToken         string           `json:"access_token"`
+	TokenType           string           `json:"token_type"`
+	Scope               string           `json:"scope"`
+	BotUserID           event.UserID     `json:"bot_user_id"`
+	RefreshToken        string           `json:"refresh_token"`
+	ExpiresIn           int              `json:"expires_in"`
+	Team                *OAuthTeam       `json:"team"`
+	Enterprise          *OAuthEnterprise `json:"enterprise"`
+	IsEnterpriseInstall bool             `json:"is_enterprise_install"`
+	AuthedUser          *OAuthAuthedUser `json:"authed_user"`
+	IncomingWebhook     *IncomingWebhook `json:"incoming_webhook"`
+}
+
+// OAuthTeam represents the workspace that the app is installed to.
+type OAuthTeam struct {
+	ID   event.TeamID `json:"id"`
+	Name string       `json:"name"`
+}
+
+// OAuthEnterprise represents the Enterprise Grid organization that the app is installed to.
+type OAuthEnterprise struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// OAuthAuthedUser represents the user who installed the app.
+// AccessToken is only given when user scopes are requested.
+type OAuthAuthedUser struct {
+	ID           event.UserID `json:"id"`
+	Scope        string       `json:"scope"`
+	AccessToken  string       `json:"access_token"`
+	TokenType    string       `json:"token_type"`
+	RefreshToken string       `json:"refresh_token"`
+	ExpiresIn    int          `json:"expires_in"`
+}
+
+// IncomingWebhook is given when incoming-webhook scope is requested.
+type IncomingWebhook struct {
+	Channel          string          `json:"channel"`
+	ChannelID        event.ChannelID `json:"channel_id"`
+	ConfigurationURL string          `json:"configuration_url"`
+	URL              string          `json:"url"`
+}
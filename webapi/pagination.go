@@ -0,0 +1,305 @@
+package webapi
+
+import (
+	"context"
+	"github.com/oklahomer/golack/v2/event"
+	"net/url"
+)
+
+// Page is a response of a cursor-paginated method.
+// Any response struct that embeds APIResponse satisfies this.
+// https://api.slack.com/docs/pagination
+type Page interface {
+	Err(slackMethod string) error
+	NextCursor() string
+}
+
+// Paginator repeatedly calls a cursor-paginated method and yields its responses page by page.
+// Each request goes through Client.Get, so throttling and retries on HTTP 429 are applied to every page.
+//
+//	paginator := client.Paginate("conversations.list", params, func() webapi.Page {
+//		return &webapi.ConversationsList{}
+//	})
+//	for paginator.Next(ctx) {
+//		page := paginator.Page().(*webapi.ConversationsList)
+//		// Do something with page.Channels
+//	}
+//	if err := paginator.Err(); err != nil {
+//		// Handle error
+//	}
+type Paginator struct {
+	client      *Client
+	slackMethod string
+	params      url.Values
+	newPage     func() Page
+	cursor      string
+	page        Page
+	done        bool
+	err         error
+}
+
+// Paginate returns Paginator for the given method.
+// The params are sent with every request, and "cursor" is set to fetch the subsequent pages.
+// Set "limit" to specify the page size.
+// newPage must return a pointer to a new response struct for each page.
+func (client *Client) Paginate(slackMethod string, params url.Values, newPage func() Page) *Paginator {
+	return &Paginator{
+		client:      client,
+		slackMethod: slackMethod,
+		params:      params,
+		newPage:     newPage,
+	}
+}
+
+// Next fetches the next page and returns true when it is available through Page.
+// False is returned when the last page is already fetched, the given context is canceled, or the request fails.
+// Check Err to distinguish the failure from the end of pages.
+func (p *Paginator) Next(ctx context.Context) bool {
+	if p.done {
+		return false
+	}
+
+	err := ctx.Err()
+	if err != nil {
+		p.fail(err)
+		return false
+	}
+
+	params := url.Values{}
+	for key, values := range p.params {
+		params[key] = values
+	}
+	if p.cursor != "" {
+		params.Set("cursor", p.cursor)
+	}
+
+	page := p.newPage()
+	err = p.client.Get(ctx, p.slackMethod, params, page)
+	if err == nil {
+		err = page.Err(p.slackMethod)
+	}
+	if err != nil {
+		p.fail(err)
+		return false
+	}
+
+	p.page = page
+	p.cursor = page.NextCursor()
+	if p.cursor == "" {
+		p.done = true
+	}
+
+	return true
+}
+
+// Page returns the page fetched by the last call to Next.
+func (p *Paginator) Page() Page {
+	return p.page
+}
+
+// Err returns the error that stopped the pagination, if any.
+func (p *Paginator) Err() error {
+	return p.err
+}
+
+func (p *Paginator) fail(err error) {
+	p.err = err
+	p.page = nil
+	p.done = true
+}
+
+// ConversationsList is a response of conversations.list method.
+// https://api.slack.com/methods/conversations.list
+type ConversationsList struct {
+	APIResponse
+	Channels []Conversation `json:"channels"`
+}
+
+// ConversationsMembers is a response of conversations.members method.
+// https://api.slack.com/methods/conversations.members
+type ConversationsMembers struct {
+	APIResponse
+	Members []string `json:"members"`
+}
+
+// UsersList is a response of users.list method.
+// https://api.slack.com/methods/users.list
+type UsersList struct {
+	APIResponse
+	Members []User `json:"members"`
+}
+
+// ConversationIterator iterates over conversations returned by conversations.list.
+type ConversationIterator struct {
+	paginator *Paginator
+	items     []Conversation
+	current   *Conversation
+}
+
+// IterateConversations returns ConversationIterator to iterate over conversations in the workspace.
+// The params are passed to conversations.list as they are, so "types" and "exclude_archived" can be specified.
+func (client *Client) IterateConversations(params url.Values) *ConversationIterator {
+	return &ConversationIterator{
+		paginator: client.Paginate("conversations.list", params, func() Page {
+			return &ConversationsList{}
+		}),
+	}
+}
+
+// Next advances to the next conversation and fetches the next page when required.
+func (it *ConversationIterator) Next(ctx context.Context) bool {
+	for len(it.items) == 0 {
+		if !it.paginator.Next(ctx) {
+			it.current = nil
+			return false
+		}
+		it.items = it.paginator.Page().(*ConversationsList).Channels
+	}
+
+	it.current = &it.items[0]
+	it.items = it.items[1:]
+	return true
+}
+
+// Conversation returns the current conversation.
+func (it *ConversationIterator) Conversation() *Conversation {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *ConversationIterator) Err() error {
+	return it.paginator.Err()
+}
+
+// MessageIterator iterates over messages returned by conversations.history.
+type MessageIterator struct {
+	paginator *Paginator
+	items     []ConversationMessage
+	current   *ConversationMessage
+}
+
+// IterateHistory returns MessageIterator to iterate over messages in the given channel from the newest to the oldest.
+// The params are passed to conversations.history as they are, so "oldest" and "latest" can be specified.
+func (client *Client) IterateHistory(channelID event.ChannelID, params url.Values) *MessageIterator {
+	return &MessageIterator{
+		paginator: client.Paginate("conversations.history", withChannel(channelID, params), func() Page {
+			return &ConversationsHistory{}
+		}),
+	}
+}
+
+// Next advances to the next message and fetches the next page when required.
+func (it *MessageIterator) Next(ctx context.Context) bool {
+	for len(it.items) == 0 {
+		if !it.paginator.Next(ctx) {
+			it.current = nil
+			return false
+		}
+		it.items = it.paginator.Page().(*ConversationsHistory).Messages
+	}
+
+	it.current = &it.items[0]
+	it.items = it.items[1:]
+	return true
+}
+
+// Message returns the current message.
+func (it *MessageIterator) Message() *ConversationMessage {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *MessageIterator) Err() error {
+	return it.paginator.Err()
+}
+
+// MemberIterator iterates over user IDs returned by conversations.members.
+type MemberIterator struct {
+	paginator *Paginator
+	items     []string
+	current   event.UserID
+}
+
+// IterateMembers returns MemberIterator to iterate over members of the given channel.
+func (client *Client) IterateMembers(channelID event.ChannelID, params url.Values) *MemberIterator {
+	return &MemberIterator{
+		paginator: client.Paginate("conversations.members", withChannel(channelID, params), func() Page {
+			return &ConversationsMembers{}
+		}),
+	}
+}
+
+// Next advances to the next member and fetches the next page when required.
+func (it *MemberIterator) Next(ctx context.Context) bool {
+	for len(it.items) == 0 {
+		if !it.paginator.Next(ctx) {
+			it.current = ""
+			return false
+		}
+		it.items = it.paginator.Page().(*ConversationsMembers).Members
+	}
+
+	it.current = event.UserID(it.items[0])
+	it.items = it.items[1:]
+	return true
+}
+
+// UserID returns the ID of the current member.
+func (it *MemberIterator) UserID() event.UserID {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *MemberIterator) Err() error {
+	return it.paginator.Err()
+}
+
+// UserIterator iterates over users returned by users.list.
+type UserIterator struct {
+	paginator *Paginator
+	items     []User
+	current   *User
+}
+
+// IterateUsers returns UserIterator to iterate over users in the workspace.
+func (client *Client) IterateUsers(params url.Values) *UserIterator {
+	return &UserIterator{
+		paginator: client.Paginate("users.list", params, func() Page {
+			return &UsersList{}
+		}),
+	}
+}
+
+// Next advances to the next user and fetches the next page when required.
+func (it *UserIterator) Next(ctx context.Context) bool {
+	for len(it.items) == 0 {
+		if !it.paginator.Next(ctx) {
+			it.current = nil
+			return false
+		}
+		it.items = it.paginator.Page().(*UsersList).Members
+	}
+
+	it.current = &it.items[0]
+	it.items = it.items[1:]
+	return true
+}
+
+// User returns the current user.
+func (it *UserIterator) User() *User {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *UserIterator) Err() error {
+	return it.paginator.Err()
+}
+
+func withChannel(channelID event.ChannelID, params url.Values) url.Values {
+	values := url.Values{}
+	for key, v := range params {
+		values[key] = v
+	}
+	values.Set("channel", channelID.String())
+	return values
+}
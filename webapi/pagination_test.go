@@ -0,0 +1,243 @@
+package webapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func newPaginationClient(slackMethod string, handler http.HandlerFunc, options ...ClientOption) *Client {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/"+slackMethod, handler)
+	client := &Client{
+		config: &Config{
+			Token:          "abc",
+			RequestTimeout: 3 * time.Second,
+		},
+		httpClient: &http.Client{Transport: &localRoundTripper{mux: mux}},
+	}
+	for _, opt := range options {
+		opt(client)
+	}
+	return client
+}
+
+// pagedHandler returns the given JSON fragments one page at a time.
+// Page n is requested with cursor "cursor-n", and the last page is returned without next_cursor.
+func pagedHandler(t *testing.T, pages []string) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		index := 0
+		if cursor := req.URL.Query().Get("cursor"); cursor != "" {
+			_, err := fmt.Sscanf(cursor, "cursor-%d", &index)
+			if err != nil {
+				t.Errorf("Unexpected cursor is given: %s.", cursor)
+			}
+		}
+
+		next := ""
+		if index+1 < len(pages) {
+			next = fmt.Sprintf("cursor-%d", index+1)
+		}
+		fmt.Fprintf(w, `{"ok": true, %s, "response_metadata": {"next_cursor": "%s"}}`, pages[index], next)
+	}
+}
+
+func TestClient_Paginate(t *testing.T) {
+	newPage := func() Page {
+		return &ConversationsMembers{}
+	}
+
+	t.Run("all pages", func(t *testing.T) {
+		var limits []string
+		handler := pagedHandler(t, []string{`"members": ["U1", "U2"]`, `"members": ["U3"]`})
+		client := newPaginationClient("conversations.members", func(w http.ResponseWriter, req *http.Request) {
+			limits = append(limits, req.URL.Query().Get("limit"))
+			handler(w, req)
+		})
+
+		paginator := client.Paginate("conversations.members", url.Values{"limit": []string{"2"}}, newPage)
+
+		var members []string
+		for paginator.Next(context.TODO()) {
+			members = append(members, paginator.Page().(*ConversationsMembers).Members...)
+		}
+
+		if paginator.Err() != nil {
+			t.Fatalf("Unexpected error is returned: %s.", paginator.Err().Error())
+		}
+
+		if len(members) != 3 || members[2] != "U3" {
+			t.Errorf("Unexpected members are returned: %#v.", members)
+		}
+
+		if len(limits) != 2 || limits[0] != "2" || limits[1] != "2" {
+			t.Errorf("Parameters are not sent with every request: %#v.", limits)
+		}
+
+		if paginator.Next(context.TODO()) {
+			t.Error("Paginator continues after the last page.")
+		}
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		client := newPaginationClient("conversations.members", func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte(`{"ok": false, "error": "channel_not_found"}`))
+		})
+
+		paginator := client.Paginate("conversations.members", nil, newPage)
+		if paginator.Next(context.TODO()) {
+			t.Fatal("Page is returned on error.")
+		}
+
+		var apiErr *APIError
+		if !errors.As(paginator.Err(), &apiErr) || apiErr.Code != "channel_not_found" {
+			t.Errorf("Expected error is not returned: %#v.", paginator.Err())
+		}
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		client := newPaginationClient("conversations.members", pagedHandler(t, []string{`"members": ["U1"]`, `"members": ["U2"]`}))
+		paginator := client.Paginate("conversations.members", nil, newPage)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		if !paginator.Next(ctx) {
+			t.Fatalf("First page is not returned: %#v.", paginator.Err())
+		}
+
+		cancel()
+		if paginator.Next(ctx) {
+			t.Fatal("Page is returned after cancellation.")
+		}
+
+		if paginator.Err() != context.Canceled {
+			t.Errorf("Expected error is not returned: %#v.", paginator.Err())
+		}
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		requested := 0
+		handler := pagedHandler(t, []string{`"members": ["U1"]`, `"members": ["U2"]`})
+		client := newPaginationClient("conversations.members", func(w http.ResponseWriter, req *http.Request) {
+			requested++
+			if requested == 2 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			handler(w, req)
+		}, WithRateLimitRetry(1))
+
+		paginator := client.Paginate("conversations.members", nil, newPage)
+		pages := 0
+		for paginator.Next(context.TODO()) {
+			pages++
+		}
+
+		if paginator.Err() != nil {
+			t.Fatalf("Unexpected error is returned: %s.", paginator.Err().Error())
+		}
+
+		if pages != 2 || requested != 3 {
+			t.Errorf("Unexpected number of pages and requests: %d, %d.", pages, requested)
+		}
+	})
+}
+
+func TestClient_IterateConversations(t *testing.T) {
+	client := newPaginationClient("conversations.list", pagedHandler(t, []string{
+		`"channels": [{"id": "C1"}, {"id": "C2"}]`,
+		`"channels": []`,
+		`"channels": [{"id": "C3"}]`,
+	}))
+
+	it := client.IterateConversations(url.Values{"types": []string{"public_channel"}})
+	var ids []string
+	for it.Next(context.TODO()) {
+		ids = append(ids, it.Conversation().ID.String())
+	}
+
+	if it.Err() != nil {
+		t.Fatalf("Unexpected error is returned: %s.", it.Err().Error())
+	}
+
+	if len(ids) != 3 || ids[0] != "C1" || ids[2] != "C3" {
+		t.Errorf("Unexpected channels are returned: %#v.", ids)
+	}
+
+	if it.Conversation() != nil {
+		t.Error("Conversation is returned after the iteration.")
+	}
+}
+
+func TestClient_IterateHistory(t *testing.T) {
+	handler := pagedHandler(t, []string{
+		`"messages": [{"type": "message", "ts": "2.0", "text": "World"}], "has_more": true`,
+		`"messages": [{"type": "message", "ts": "1.0", "text": "Hello"}], "has_more": false`,
+	})
+	client := newPaginationClient("conversations.history", func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("channel") != "C123" {
+			t.Errorf("Channel is not given: %s.", req.URL.RawQuery)
+		}
+		handler(w, req)
+	})
+
+	it := client.IterateHistory("C123", nil)
+	var timeStamps []string
+	for it.Next(context.TODO()) {
+		timeStamps = append(timeStamps, it.Message().TimeStamp.String())
+	}
+
+	if it.Err() != nil {
+		t.Fatalf("Unexpected error is returned: %s.", it.Err().Error())
+	}
+
+	if len(timeStamps) != 2 || timeStamps[0] != "2.0" || timeStamps[1] != "1.0" {
+		t.Errorf("Unexpected messages are returned: %#v.", timeStamps)
+	}
+}
+
+func TestClient_IterateMembers(t *testing.T) {
+	client := newPaginationClient("conversations.members", pagedHandler(t, []string{
+		`"members": ["U1"]`,
+		`"members": ["U2"]`,
+	}))
+
+	it := client.IterateMembers("C123", nil)
+	var ids []string
+	for it.Next(context.TODO()) {
+		ids = append(ids, it.UserID().String())
+	}
+
+	if it.Err() != nil {
+		t.Fatalf("Unexpected error is returned: %s.", it.Err().Error())
+	}
+
+	if len(ids) != 2 || ids[0] != "U1" || ids[1] != "U2" {
+		t.Errorf("Unexpected members are returned: %#v.", ids)
+	}
+}
+
+func TestClient_IterateUsers(t *testing.T) {
+	client := newPaginationClient("users.list", pagedHandler(t, []string{
+		`"members": [{"id": "U1", "name": "foo"}]`,
+		`"members": [{"id": "U2", "name": "bar"}]`,
+	}))
+
+	it := client.IterateUsers(nil)
+	var names []string
+	for it.Next(context.TODO()) {
+		names = append(names, it.User().Name)
+	}
+
+	if it.Err() != nil {
+		t.Fatalf("Unexpected error is returned: %s.", it.Err().Error())
+	}
+
+	if len(names) != 2 || names[0] != "foo" || names[1] != "bar" {
+		t.Errorf("Unexpected users are returned: %#v.", names)
+	}
+}
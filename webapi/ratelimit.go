@@ -0,0 +1,255 @@
+package webapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimitedError is returned when Slack responds with HTTP 429 and the retry budget is exhausted.
+// https://api.slack.com/docs/rate-limits
+type RateLimitedError struct {
+	Method     string
+	RetryAfter time.Duration
+}
+
+var _ error = (*RateLimitedError)(nil)
+
+// Error returns its error string.
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("rate limited on %s request. retry after %s", e.Method, e.RetryAfter)
+}
+
+// RateLimitTier represents the rate limit tier that Slack assigns to each Web API method.
+// https://api.slack.com/docs/rate-limits#tiers
+type RateLimitTier int
+
+const (
+	// Tier1 allows 1+ requests per minute.
+	Tier1 RateLimitTier = iota + 1
+
+	// Tier2 allows 20+ requests per minute.
+	Tier2
+
+	// Tier3 allows 50+ requests per minute.
+	Tier3
+
+	// Tier4 allows 100+ requests per minute.
+	Tier4
+
+	// TierPerChannel allows 1 request per second for each channel.
+	// This is applied to chat.postMessage.
+	TierPerChannel
+)
+
+func (tier RateLimitTier) interval() time.Duration {
+	switch tier {
+	case Tier1:
+		return 1 * time.Minute
+
+	case Tier2:
+		return time.Minute / 20
+
+	case Tier3:
+		return time.Minute / 50
+
+	case Tier4:
+		return time.Minute / 100
+
+	case TierPerChannel:
+		return 1 * time.Second
+
+	default:
+		return 0
+
+	}
+}
+
+// DefaultRateLimitTiers maps Web API methods to their rate limit tiers.
+// Pass this or a customized map to WithThrottling.
+var DefaultRateLimitTiers = map[string]RateLimitTier{
+	"apps.connections.open": Tier1,
+	"rtm.connect":           Tier1,
+	"rtm.start":             Tier1,
+
+	"bookmarks.add":            Tier2,
+	"bookmarks.edit":           Tier2,
+	"bookmarks.list":           Tier2,
+	"bookmarks.remove":         Tier2,
+	"conversations.archive":    Tier2,
+	"conversations.close":      Tier2,
+	"conversations.create":     Tier2,
+	"conversations.list":       Tier2,
+	"conversations.rename":     Tier2,
+	"conversations.setPurpose": Tier2,
+	"conversations.setTopic":   Tier2,
+	"conversations.unarchive":  Tier2,
+	"files.upload":             Tier2,
+	"pins.add":                 Tier2,
+	"pins.list":                Tier2,
+	"pins.remove":              Tier2,
+	"reactions.list":           Tier2,
+	"reactions.remove":         Tier2,
+	"stars.add":                Tier2,
+	"stars.remove":             Tier2,
+	"users.list":               Tier2,
+	"users.setPresence":        Tier2,
+
+	"chat.delete":                 Tier3,
+	"chat.deleteScheduledMessage": Tier3,
+	"chat.meMessage":              Tier3,
+	"chat.scheduleMessage":        Tier3,
+	"chat.scheduledMessages.list": Tier3,
+	"chat.update":                 Tier3,
+	"conversations.history":       Tier3,
+	"conversations.info":          Tier3,
+	"conversations.invite":        Tier3,
+	"conversations.join":          Tier3,
+	"conversations.kick":          Tier3,
+	"conversations.leave":         Tier3,
+	"conversations.mark":          Tier3,
+	"conversations.members":       Tier3,
+	"conversations.open":          Tier3,
+	"conversations.replies":       Tier3,
+	"reactions.add":               Tier3,
+	"reactions.get":               Tier3,
+	"users.getPresence":           Tier3,
+	"users.lookupByEmail":         Tier3,
+	"users.profile.set":           Tier3,
+
+	"chat.getPermalink":            Tier4,
+	"chat.postEphemeral":           Tier4,
+	"files.completeUploadExternal": Tier4,
+	"files.getUploadURLExternal":   Tier4,
+	"oauth.v2.access":              Tier4,
+	"users.info":                   Tier4,
+	"users.profile.get":            Tier4,
+	"views.open":                   Tier4,
+	"views.publish":                Tier4,
+	"views.push":                   Tier4,
+	"views.update":                 Tier4,
+
+	"chat.postMessage": TierPerChannel,
+}
+
+// WithRateLimitRetry sets the number of retries on HTTP 429.
+// Before each retry, the client waits for the duration given by Retry-After header while respecting the given context.
+// When the retries are exhausted, *RateLimitedError is returned.
+// By default, no retry is made.
+func WithRateLimitRetry(maxRetries int) ClientOption {
+	return func(c *Client) {
+		c.rateLimitRetries = maxRetries
+	}
+}
+
+// WithThrottling enables pre-throttling so requests are spaced out to stay within each method's rate limit tier.
+// When nil is given, DefaultRateLimitTiers is used.
+// Methods that are not listed are not throttled, but are still held after HTTP 429 is returned.
+func WithThrottling(tiers map[string]RateLimitTier) ClientOption {
+	return func(c *Client) {
+		if tiers == nil {
+			tiers = DefaultRateLimitTiers
+		}
+		c.throttler = newThrottler(tiers)
+	}
+}
+
+// throttler reserves the time slot for each request to stay within the rate limit.
+type throttler struct {
+	tiers map[string]RateLimitTier
+	next  map[string]time.Time
+	mutex *sync.Mutex
+}
+
+func newThrottler(tiers map[string]RateLimitTier) *throttler {
+	return &throttler{
+		tiers: tiers,
+		next:  map[string]time.Time{},
+		mutex: &sync.Mutex{},
+	}
+}
+
+// wait blocks until the request is allowed to be sent.
+func (t *throttler) wait(ctx context.Context, slackMethod string, channel string) error {
+	now := time.Now()
+	at := t.reserve(slackMethod, channel, now)
+	return sleep(ctx, at.Sub(now))
+}
+
+// reserve returns the time when the request is allowed to be sent, and reserves the slot.
+func (t *throttler) reserve(slackMethod string, channel string, now time.Time) time.Time {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	tier := t.tiers[slackMethod]
+	key := t.key(slackMethod, tier, channel)
+
+	at := t.next[key]
+	if at.Before(now) {
+		at = now
+	}
+	t.next[key] = at.Add(tier.interval())
+
+	return at
+}
+
+// pause holds any request to the given method until the given duration passes.
+func (t *throttler) pause(slackMethod string, channel string, d time.Duration) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	key := t.key(slackMethod, t.tiers[slackMethod], channel)
+	at := time.Now().Add(d)
+	if t.next[key].Before(at) {
+		t.next[key] = at
+	}
+}
+
+func (t *throttler) key(slackMethod string, tier RateLimitTier, channel string) string {
+	if tier == TierPerChannel {
+		return slackMethod + ":" + channel
+	}
+	return slackMethod
+}
+
+// parseRetryAfter parses the value of Retry-After header.
+// Slack returns the number of seconds, but HTTP-date is also accepted as RFC 7231 defines.
+// One second is returned when the value is not given or invalid.
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(value)
+	if err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	date, err := http.ParseTime(value)
+	if err == nil {
+		d := time.Until(date)
+		if d < 0 {
+			return 0
+		}
+		return d
+	}
+
+	return 1 * time.Second
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+
+	case <-ctx.Done():
+		return ctx.Err()
+
+	}
+}
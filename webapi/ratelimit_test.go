@@ -0,0 +1,234 @@
+package webapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithRateLimitRetry(t *testing.T) {
+	option := WithRateLimitRetry(3)
+	client := &Client{}
+
+	option(client)
+
+	if client.rateLimitRetries != 3 {
+		t.Errorf("Specified retry budget is not set: %d.", client.rateLimitRetries)
+	}
+}
+
+func TestWithThrottling(t *testing.T) {
+	t.Run("default tiers", func(t *testing.T) {
+		client := &Client{}
+		WithThrottling(nil)(client)
+
+		if client.throttler == nil {
+			t.Fatal("Throttler is not set.")
+		}
+
+		if client.throttler.tiers["chat.postMessage"] != TierPerChannel {
+			t.Error("Default tiers are not set.")
+		}
+	})
+
+	t.Run("custom tiers", func(t *testing.T) {
+		tiers := map[string]RateLimitTier{"foo": Tier1}
+		client := &Client{}
+		WithThrottling(tiers)(client)
+
+		if client.throttler.tiers["foo"] != Tier1 {
+			t.Error("Specified tiers are not set.")
+		}
+	})
+}
+
+func TestClient_RateLimited(t *testing.T) {
+	newClient := func(handler http.HandlerFunc, options ...ClientOption) *Client {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/chat.postMessage", handler)
+		client := &Client{
+			config: &Config{
+				Token:          "abc",
+				RequestTimeout: 3 * time.Second,
+			},
+			httpClient: &http.Client{Transport: &localRoundTripper{mux: mux}},
+		}
+		for _, opt := range options {
+			opt(client)
+		}
+		return client
+	}
+
+	t.Run("without retry", func(t *testing.T) {
+		client := newClient(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+
+		err := client.Post(context.TODO(), "chat.postMessage", url.Values{"channel": []string{"C123"}}, &APIResponse{})
+
+		var rateLimited *RateLimitedError
+		if !errors.As(err, &rateLimited) {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+
+		if rateLimited.Method != "chat.postMessage" {
+			t.Errorf("Unexpected method is set: %s.", rateLimited.Method)
+		}
+
+		if rateLimited.RetryAfter != 30*time.Second {
+			t.Errorf("Unexpected duration is set: %s.", rateLimited.RetryAfter)
+		}
+	})
+
+	t.Run("retry after the given duration", func(t *testing.T) {
+		mutex := &sync.Mutex{}
+		requested := 0
+		client := newClient(func(w http.ResponseWriter, _ *http.Request) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			requested++
+			if requested == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"ok": true}`))
+		}, WithRateLimitRetry(1))
+
+		start := time.Now()
+		response := &APIResponse{}
+		err := client.Post(context.TODO(), "chat.postMessage", url.Values{"channel": []string{"C123"}}, response)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Error("Response of the retried request is not decoded.")
+		}
+
+		if time.Since(start) < 1*time.Second {
+			t.Errorf("Retry-After is not respected: %s.", time.Since(start))
+		}
+
+		if requested != 2 {
+			t.Errorf("Unexpected number of requests: %d.", requested)
+		}
+	})
+
+	t.Run("retry budget is exhausted", func(t *testing.T) {
+		requested := 0
+		client := newClient(func(w http.ResponseWriter, _ *http.Request) {
+			requested++
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}, WithRateLimitRetry(2))
+
+		err := client.Post(context.TODO(), "chat.postMessage", url.Values{}, &APIResponse{})
+		if _, ok := err.(*RateLimitedError); !ok {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+
+		if requested != 3 {
+			t.Errorf("Unexpected number of requests: %d.", requested)
+		}
+	})
+
+	t.Run("context cancellation while waiting", func(t *testing.T) {
+		client := newClient(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}, WithRateLimitRetry(1))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		err := client.Post(ctx, "chat.postMessage", url.Values{}, &APIResponse{})
+		if err != context.DeadlineExceeded {
+			t.Errorf("Expected error is not returned: %#v.", err)
+		}
+	})
+}
+
+func Test_throttler_reserve(t *testing.T) {
+	throttler := newThrottler(map[string]RateLimitTier{
+		"chat.postMessage": TierPerChannel,
+		"users.list":       Tier2,
+	})
+	now := time.Now()
+
+	// Requests to the same channel are spaced out
+	first := throttler.reserve("chat.postMessage", "C123", now)
+	second := throttler.reserve("chat.postMessage", "C123", now)
+	if !first.Equal(now) || !second.Equal(now.Add(1*time.Second)) {
+		t.Errorf("Unexpected slots are reserved: %s, %s.", first, second)
+	}
+
+	// Another channel is not affected
+	other := throttler.reserve("chat.postMessage", "C999", now)
+	if !other.Equal(now) {
+		t.Errorf("Unexpected slot is reserved: %s.", other)
+	}
+
+	first = throttler.reserve("users.list", "", now)
+	second = throttler.reserve("users.list", "", now)
+	if !second.Equal(first.Add(3 * time.Second)) {
+		t.Errorf("Unexpected slots are reserved: %s, %s.", first, second)
+	}
+
+	// Unknown methods are not throttled
+	first = throttler.reserve("foo", "", now)
+	second = throttler.reserve("foo", "", now)
+	if !first.Equal(now) || !second.Equal(now) {
+		t.Errorf("Unexpected slots are reserved: %s, %s.", first, second)
+	}
+}
+
+func Test_throttler_pause(t *testing.T) {
+	throttler := newThrottler(map[string]RateLimitTier{})
+
+	throttler.pause("foo", "", 10*time.Second)
+
+	now := time.Now()
+	at := throttler.reserve("foo", "", now)
+	if at.Sub(now) < 9*time.Second {
+		t.Errorf("Request is not held: %s.", at.Sub(now))
+	}
+}
+
+func Test_parseRetryAfter(t *testing.T) {
+	if d := parseRetryAfter("30"); d != 30*time.Second {
+		t.Errorf("Unexpected duration is returned: %s.", d)
+	}
+
+	if d := parseRetryAfter(""); d != 1*time.Second {
+		t.Errorf("Unexpected duration is returned: %s.", d)
+	}
+
+	date := time.Now().Add(1 * time.Minute).UTC().Format(http.TimeFormat)
+	if d := parseRetryAfter(date); d <= 0 || d > 1*time.Minute {
+		t.Errorf("Unexpected duration is returned: %s.", d)
+	}
+}
+
+func Test_payload_channel(t *testing.T) {
+	p, err := genPayload("chat.postMessage", NewPostMessage("C123", "Hello"))
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+	if p.channel() != "C123" {
+		t.Errorf("Channel is not extracted from JSON payload: %s.", p.channel())
+	}
+
+	p, err = genPayload("chat.postMessage", url.Values{"channel": []string{"C999"}})
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+	if p.channel() != "C999" {
+		t.Errorf("Channel is not extracted from form payload: %s.", p.channel())
+	}
+}
@@ -5,8 +5,43 @@ type TimeStamp int64
 // APIResponse provides common fields shared by all API response.
 // https://api.slack.com/web#basics
 type APIResponse struct {
-	OK    bool   `json:"ok"`
-	Error string `json:"error"`
+	OK      bool   `json:"ok"`
+	Error   string `json:"error"`
+	Warning string `json:"warning"`
+
+	// Needed and Provided are given with missing_scope error.
+	Needed   string `json:"needed"`
+	Provided string `json:"provided"`
+
+	ResponseMetadata *ResponseMetadata `json:"response_metadata"`
+}
+
+// Err returns *APIError when the response represents a failure.
+// Nil is returned when the response is ok.
+func (r *APIResponse) Err(slackMethod string) error {
+	if r.OK {
+		return nil
+	}
+	return NewAPIError(slackMethod, r)
+}
+
+// NextCursor returns the cursor to fetch the next page, or an empty string when this is the last page.
+func (r *APIResponse) NextCursor() string {
+	if r.ResponseMetadata == nil {
+		return ""
+	}
+	return r.ResponseMetadata.NextCursor
+}
+
+// ResponseMetadata provides supplemental information of the response.
+// https://api.slack.com/web#responses
+type ResponseMetadata struct {
+	Messages []string `json:"messages"`
+	Warnings []string `json:"warnings"`
+
+	// NextCursor is given by cursor-paginated methods. This is empty on the last page.
+	// https://api.slack.com/docs/pagination
+	NextCursor string `json:"next_cursor"`
 }
 
 // Self contains details on the authenticated user.
@@ -33,10 +68,17 @@ type UserProfile struct {
 	Image192           string `json:"image_192"`
 	ImageOriginal      string `json:"image_original"`
 	Title              string `json:"title"`
+
+	DisplayName           string `json:"display_name"`
+	DisplayNameNormalized string `json:"display_name_normalized"`
+	StatusText            string `json:"status_text"`
+	StatusEmoji           string `json:"status_emoji"`
+	StatusExpiration      int64  `json:"status_expiration"`
 }
 
 // User contains all the information of a user
 type User struct {
+	ID                string      `json:"id"`
 	User              string      `json:"user"`
 	Name              string      `json:"name"`
 	Deleted           bool        `json:"deleted"`
@@ -157,3 +199,11 @@ type RTMStart struct {
 	Bots     []Bot     `json:"bots"`
 	IMs      []IM      `json:"ims"`
 }
+
+// AppsConnectionsOpen is a response of apps.connections.open method.
+// The returned URL is used to establish Socket Mode connection.
+// https://api.slack.com/methods/apps.connections.open
+type AppsConnectionsOpen struct {
+	APIResponse
+	URL string `json:"url"`
+}
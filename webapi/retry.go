@@ -0,0 +1,160 @@
+package webapi
+
+import (
+	"context"
+	"github.com/oklahomer/golack/v2/internal/backoff"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// NonIdempotentMethods lists Web API methods that may cause duplicated side effects such as duplicated messages when retried.
+// RetryPolicy does not retry these methods on failures unless RetryNonIdempotent is set.
+var NonIdempotentMethods = []string{
+	"chat.meMessage",
+	"chat.postEphemeral",
+	"chat.postMessage",
+	"chat.scheduleMessage",
+	"conversations.create",
+	"files.completeUploadExternal",
+	"files.upload",
+	"reminders.add",
+	"usergroups.create",
+	"views.open",
+	"views.push",
+}
+
+// RetryEvent describes a retry that is about to happen.
+// This is passed to RetryPolicy.OnRetry.
+type RetryEvent struct {
+	Method string
+
+	// Attempt is the number of the attempt that has just failed. This starts from 1.
+	Attempt int
+
+	// Wait is the duration to wait before the next attempt.
+	Wait time.Duration
+
+	// Err is the cause of the retry.
+	// This is *RateLimitedError on HTTP 429, *StatusError on unexpected HTTP status,
+	// *APIError with retryable error code, or a transport error returned by http.Client.
+	Err error
+}
+
+// RetryPolicy decides whether and when a failed request is retried.
+// Use NewRetryPolicy to build one with default settings and pass it to WithRetryPolicy.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one.
+	MaxAttempts int
+
+	// The interval grows exponentially by Multiplier from InitialInterval until it reaches MaxInterval, and is randomized by Jitter.
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	Multiplier      float64
+	Jitter          float64
+
+	// RetryableStatuses lists HTTP status codes to retry.
+	RetryableStatuses []int
+
+	// RetryableErrorCodes lists the error codes given with "ok": false to retry.
+	RetryableErrorCodes []string
+
+	// NonIdempotentMethods are not retried except on HTTP 429 unless RetryNonIdempotent is true.
+	NonIdempotentMethods []string
+	RetryNonIdempotent   bool
+
+	// OnRetry is called before every retry including the one on HTTP 429.
+	OnRetry func(*RetryEvent)
+}
+
+// NewRetryPolicy returns RetryPolicy with default settings.
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:     3,
+		InitialInterval: 500 * time.Millisecond,
+		MaxInterval:     10 * time.Second,
+		Multiplier:      2,
+		Jitter:          0.2,
+		RetryableStatuses: []int{
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryableErrorCodes: []string{
+			"internal_error",
+			"fatal_error",
+			"service_unavailable",
+			"request_timeout",
+		},
+		NonIdempotentMethods: NonIdempotentMethods,
+	}
+}
+
+// WithRetryPolicy sets the policy to retry requests on transient failures such as 5xx statuses, connection resets and internal_error.
+// By default, no retry is made except for the one set by WithRateLimitRetry.
+func WithRetryPolicy(policy *RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// retryable tells whether the request should be retried after the given attempt failed with err.
+func (p *RetryPolicy) retryable(ctx context.Context, slackMethod string, attempt int, err error) bool {
+	if p == nil || attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+
+	if !p.RetryNonIdempotent && contains(p.NonIdempotentMethods, slackMethod) {
+		return false
+	}
+
+	switch typed := err.(type) {
+	case *StatusError:
+		for _, status := range p.RetryableStatuses {
+			if status == typed.StatusCode {
+				return true
+			}
+		}
+		return false
+
+	case *APIError:
+		return contains(p.RetryableErrorCodes, typed.Code)
+
+	case *url.Error:
+		// Errors returned by http.Client such as connection reset.
+		// The caller's context is checked above, so a cancellation here comes from the timeout of this single attempt.
+		return true
+
+	case net.Error:
+		// Errors on reading the response body
+		return true
+
+	default:
+		// Errors such as invalid payload or malformed response are not resolved by retrying
+		return false
+
+	}
+}
+
+// interval returns the duration to wait after the given attempt failed.
+func (p *RetryPolicy) interval(attempt int) time.Duration {
+	return backoff.Duration(p.InitialInterval, p.MaxInterval, p.Multiplier, p.Jitter, attempt-1)
+}
+
+func (p *RetryPolicy) notify(event *RetryEvent) {
+	if p == nil || p.OnRetry == nil {
+		return
+	}
+	p.OnRetry(event)
+}
+
+func contains(list []string, str string) bool {
+	for _, s := range list {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
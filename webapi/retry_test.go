@@ -0,0 +1,304 @@
+package webapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type failingRoundTripper struct {
+	err       error
+	failures  int
+	requested int
+	tripper   http.RoundTripper
+}
+
+func (f *failingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.requested++
+	if f.requested <= f.failures {
+		return nil, f.err
+	}
+	return f.tripper.RoundTrip(req)
+}
+
+func TestNewRetryPolicy(t *testing.T) {
+	policy := NewRetryPolicy()
+
+	if policy.MaxAttempts == 0 {
+		t.Error("Default max attempts is not set.")
+	}
+
+	if len(policy.RetryableStatuses) == 0 {
+		t.Error("Default retryable statuses are not set.")
+	}
+
+	if !contains(policy.RetryableErrorCodes, "internal_error") {
+		t.Error("Default retryable error codes are not set.")
+	}
+
+	if !contains(policy.NonIdempotentMethods, "chat.postMessage") {
+		t.Error("Default non-idempotent methods are not set.")
+	}
+}
+
+func TestWithRetryPolicy(t *testing.T) {
+	policy := &RetryPolicy{}
+	option := WithRetryPolicy(policy)
+	client := &Client{}
+
+	option(client)
+
+	if client.retryPolicy != policy {
+		t.Error("Specified policy is not set.")
+	}
+}
+
+func TestRetryPolicy_interval(t *testing.T) {
+	policy := &RetryPolicy{
+		InitialInterval: 1 * time.Second,
+		MaxInterval:     5 * time.Second,
+		Multiplier:      2,
+	}
+
+	tests := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{attempt: 1, expected: 1 * time.Second},
+		{attempt: 2, expected: 2 * time.Second},
+		{attempt: 3, expected: 4 * time.Second},
+		{attempt: 4, expected: 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		d := policy.interval(tt.attempt)
+		if d != tt.expected {
+			t.Errorf("Expected %s on attempt %d, but was %s.", tt.expected, tt.attempt, d)
+		}
+	}
+}
+
+func TestClient_Retry(t *testing.T) {
+	newPolicy := func() *RetryPolicy {
+		policy := NewRetryPolicy()
+		policy.InitialInterval = 1 * time.Millisecond
+		policy.MaxInterval = 1 * time.Millisecond
+		return policy
+	}
+
+	newClient := func(slackMethod string, handler http.HandlerFunc, policy *RetryPolicy) *Client {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/"+slackMethod, handler)
+		return &Client{
+			config: &Config{
+				Token:          "abc",
+				RequestTimeout: 3 * time.Second,
+			},
+			httpClient:  &http.Client{Transport: &localRoundTripper{mux: mux}},
+			retryPolicy: policy,
+		}
+	}
+
+	t.Run("retryable status", func(t *testing.T) {
+		requested := 0
+		var events []*RetryEvent
+		policy := newPolicy()
+		policy.OnRetry = func(event *RetryEvent) {
+			events = append(events, event)
+		}
+		client := newClient("conversations.info", func(w http.ResponseWriter, _ *http.Request) {
+			requested++
+			if requested == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"ok": true}`))
+		}, policy)
+
+		response := &APIResponse{}
+		err := client.Get(context.TODO(), "conversations.info", nil, response)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK {
+			t.Error("Response of the retried request is not decoded.")
+		}
+
+		if requested != 2 {
+			t.Errorf("Unexpected number of requests: %d.", requested)
+		}
+
+		if len(events) != 1 {
+			t.Fatalf("Hook is not called: %#v.", events)
+		}
+		if events[0].Method != "conversations.info" || events[0].Attempt != 1 {
+			t.Errorf("Unexpected event is given: %#v.", events[0])
+		}
+		if statusErr, ok := events[0].Err.(*StatusError); !ok || statusErr.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("Unexpected error is given: %#v.", events[0].Err)
+		}
+	})
+
+	t.Run("retryable error code", func(t *testing.T) {
+		requested := 0
+		client := newClient("conversations.info", func(w http.ResponseWriter, _ *http.Request) {
+			requested++
+			if requested == 1 {
+				w.Write([]byte(`{"ok": false, "error": "internal_error"}`))
+				return
+			}
+			w.Write([]byte(`{"ok": true}`))
+		}, newPolicy())
+
+		response := &APIResponse{}
+		err := client.Get(context.TODO(), "conversations.info", nil, response)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if !response.OK || response.Error != "" {
+			t.Errorf("Response of the retried request is not decoded: %#v.", response)
+		}
+
+		if requested != 2 {
+			t.Errorf("Unexpected number of requests: %d.", requested)
+		}
+	})
+
+	t.Run("non-retryable error code", func(t *testing.T) {
+		requested := 0
+		client := newClient("conversations.info", func(w http.ResponseWriter, _ *http.Request) {
+			requested++
+			w.Write([]byte(`{"ok": false, "error": "channel_not_found"}`))
+		}, newPolicy())
+
+		response := &APIResponse{}
+		err := client.Get(context.TODO(), "conversations.info", nil, response)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if response.Error != "channel_not_found" {
+			t.Errorf("Error code is not decoded: %#v.", response)
+		}
+
+		if requested != 1 {
+			t.Errorf("Unexpected number of requests: %d.", requested)
+		}
+	})
+
+	t.Run("max attempts", func(t *testing.T) {
+		requested := 0
+		policy := newPolicy()
+		policy.MaxAttempts = 3
+		client := newClient("conversations.info", func(w http.ResponseWriter, _ *http.Request) {
+			requested++
+			w.WriteHeader(http.StatusInternalServerError)
+		}, policy)
+
+		err := client.Get(context.TODO(), "conversations.info", nil, &APIResponse{})
+		if _, ok := err.(*StatusError); !ok {
+			t.Fatalf("Expected error is not returned: %#v.", err)
+		}
+
+		if requested != 3 {
+			t.Errorf("Unexpected number of requests: %d.", requested)
+		}
+	})
+
+	t.Run("non-idempotent method", func(t *testing.T) {
+		requested := 0
+		handler := func(w http.ResponseWriter, _ *http.Request) {
+			requested++
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+
+		client := newClient("chat.postMessage", handler, newPolicy())
+		err := client.Post(context.TODO(), "chat.postMessage", url.Values{}, &APIResponse{})
+		if err == nil {
+			t.Fatal("Expected error is not returned.")
+		}
+		if requested != 1 {
+			t.Errorf("Non-idempotent method is retried: %d.", requested)
+		}
+
+		requested = 0
+		policy := newPolicy()
+		policy.RetryNonIdempotent = true
+		client = newClient("chat.postMessage", handler, policy)
+		err = client.Post(context.TODO(), "chat.postMessage", url.Values{}, &APIResponse{})
+		if err == nil {
+			t.Fatal("Expected error is not returned.")
+		}
+		if requested != policy.MaxAttempts {
+			t.Errorf("Non-idempotent method is not retried: %d.", requested)
+		}
+	})
+
+	t.Run("transport error", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/conversations.info", func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte(`{"ok": true}`))
+		})
+		tripper := &failingRoundTripper{
+			err:      errors.New("connection reset by peer"),
+			failures: 1,
+			tripper:  &localRoundTripper{mux: mux},
+		}
+		client := &Client{
+			config: &Config{
+				Token:          "abc",
+				RequestTimeout: 3 * time.Second,
+			},
+			httpClient:  &http.Client{Transport: tripper},
+			retryPolicy: newPolicy(),
+		}
+
+		err := client.Get(context.TODO(), "conversations.info", nil, &APIResponse{})
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		if tripper.requested != 2 {
+			t.Errorf("Unexpected number of requests: %d.", tripper.requested)
+		}
+	})
+
+	t.Run("malformed response", func(t *testing.T) {
+		requested := 0
+		client := newClient("conversations.info", func(w http.ResponseWriter, _ *http.Request) {
+			requested++
+			w.Write([]byte(`{"ok": `))
+		}, newPolicy())
+
+		err := client.Get(context.TODO(), "conversations.info", nil, &APIResponse{})
+		if err == nil {
+			t.Fatal("Expected error is not returned.")
+		}
+
+		if requested != 1 {
+			t.Errorf("Malformed response is retried: %d.", requested)
+		}
+	})
+
+	t.Run("without policy", func(t *testing.T) {
+		requested := 0
+		client := newClient("conversations.info", func(w http.ResponseWriter, _ *http.Request) {
+			requested++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}, nil)
+
+		err := client.Get(context.TODO(), "conversations.info", nil, &APIResponse{})
+		if err == nil {
+			t.Fatal("Expected error is not returned.")
+		}
+
+		if requested != 1 {
+			t.Errorf("Request is retried without policy: %d.", requested)
+		}
+	})
+}
@@ -0,0 +1,253 @@
+package webapi
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// TokenProvider provides the token to send with each request instead of the fixed Config.Token.
+// Implementations must be safe for concurrent use.
+type TokenProvider interface {
+	// Token returns the token for the next request.
+	Token(ctx context.Context) (string, error)
+
+	// Refresh is called when the given token is rejected with token_expired error, and returns the token to retry with.
+	// When the token is already refreshed by another caller, the current token should be returned without refreshing again.
+	Refresh(ctx context.Context, expired string) (string, error)
+}
+
+// WithTokenProvider sets TokenProvider to retrieve the token for each request.
+// When a request fails with token_expired error, the token is refreshed and the request is retried once.
+// Config.Token is ignored when this is set.
+func WithTokenProvider(provider TokenProvider) ClientOption {
+	return func(c *Client) {
+		c.tokenProvider = provider
+	}
+}
+
+type tokenContextKey struct{}
+
+// contextToken is either a fixed token or a provider to be carried by a context.
+type contextToken struct {
+	token    string
+	provider TokenProvider
+}
+
+// ContextWithToken returns a copy of the given context that carries the given token.
+// Client sends this token instead of Config.Token or the TokenProvider set with WithTokenProvider,
+// so one Client can serve multiple workspaces by passing the context of each incoming event.
+func ContextWithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenContextKey{}, &contextToken{token: token})
+}
+
+// ContextWithTokenProvider returns a copy of the given context that carries the given TokenProvider.
+// This works like ContextWithToken, but the token is retrieved from and refreshed by the provider.
+func ContextWithTokenProvider(ctx context.Context, provider TokenProvider) context.Context {
+	return context.WithValue(ctx, tokenContextKey{}, &contextToken{provider: provider})
+}
+
+// RotatingToken represents a short-lived access token and the refresh token to renew it.
+// When ExpiresAt is zero, the token is only refreshed when Slack rejects it as expired.
+// See https://api.slack.com/authentication/rotation
+type RotatingToken struct {
+	AccessToken  string    `json:"access_token" yaml:"access_token"`
+	RefreshToken string    `json:"refresh_token" yaml:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at" yaml:"expires_at"`
+}
+
+// RotatingTokenOption defines a function that customizes the RotatingTokenProvider.
+type RotatingTokenOption func(*RotatingTokenProvider)
+
+// WithRefreshMargin sets how long before the expiration the token is refreshed.
+// This defaults to 5 minutes.
+func WithRefreshMargin(margin time.Duration) RotatingTokenOption {
+	return func(p *RotatingTokenProvider) {
+		p.margin = margin
+	}
+}
+
+// WithTokenPersister sets a function that persists the refreshed token.
+// The refresh token is renewed along with the access token, so the refreshed one must be stored to refresh after restart.
+func WithTokenPersister(persist func(ctx context.Context, token *RotatingToken) error) RotatingTokenOption {
+	return func(p *RotatingTokenProvider) {
+		p.persist = persist
+	}
+}
+
+// WithPersistErrorHandler sets a function that is called when the persister fails to store the refreshed token.
+// The refreshed token is still used and returned to the caller, but the previous refresh token is already spent;
+// the handler is given the refreshed token so it can be stored elsewhere.
+// By default, the error is logged.
+func WithPersistErrorHandler(handler func(ctx context.Context, token *RotatingToken, err error)) RotatingTokenOption {
+	return func(p *RotatingTokenProvider) {
+		p.onPersistError = handler
+	}
+}
+
+// WithRefreshClient sets the Client to call oauth.v2.access method.
+// The Client must not be built with WithTokenProvider of this provider; otherwise the refresh request would wait for itself.
+// The Client's token is not sent since client credentials are sent instead.
+// By default, a Client with default settings is used.
+func WithRefreshClient(client *Client) RotatingTokenOption {
+	return func(p *RotatingTokenProvider) {
+		p.client = client
+	}
+}
+
+// RotatingTokenProvider is a TokenProvider implementation for apps with token rotation enabled.
+// The token is refreshed with oauth.v2.access method before it expires or when Slack rejects it as expired.
+// Concurrent requests share a single refresh, and each of them stops waiting for it when its own context is done.
+type RotatingTokenProvider struct {
+	clientID       string
+	clientSecret   string
+	token          *RotatingToken
+	margin         time.Duration
+	persist        func(ctx context.Context, token *RotatingToken) error
+	onPersistError func(ctx context.Context, token *RotatingToken, err error)
+	client         *Client
+	now            func() time.Time
+	refreshing     *refreshCall
+	mutex          *sync.Mutex
+}
+
+// refreshCall represents an in-flight refresh that concurrent callers wait for.
+type refreshCall struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+var _ TokenProvider = (*RotatingTokenProvider)(nil)
+
+// NewRotatingTokenProvider creates RotatingTokenProvider with the app's client credentials and the current token.
+func NewRotatingTokenProvider(clientID string, clientSecret string, token *RotatingToken, options ...RotatingTokenOption) *RotatingTokenProvider {
+	copied := *token
+	p := &RotatingTokenProvider{
+		clientID:       clientID,
+		clientSecret:   clientSecret,
+		token:          &copied,
+		margin:         5 * time.Minute,
+		onPersistError: logPersistError,
+		now:            time.Now,
+		mutex:          &sync.Mutex{},
+	}
+
+	for _, opt := range options {
+		opt(p)
+	}
+
+	if p.client == nil {
+		p.client = NewClient(NewConfig())
+	}
+
+	return p
+}
+
+// Token returns the current access token, or refreshes it first when it expires within the margin.
+func (p *RotatingTokenProvider) Token(ctx context.Context) (string, error) {
+	p.mutex.Lock()
+
+	if p.token.ExpiresAt.IsZero() || p.now().Add(p.margin).Before(p.token.ExpiresAt) {
+		defer p.mutex.Unlock()
+		return p.token.AccessToken, nil
+	}
+
+	return p.refresh(ctx)
+}
+
+// Refresh refreshes the token unless the given token is already replaced by another caller.
+func (p *RotatingTokenProvider) Refresh(ctx context.Context, expired string) (string, error) {
+	p.mutex.Lock()
+
+	if p.token.AccessToken != expired {
+		defer p.mutex.Unlock()
+		return p.token.AccessToken, nil
+	}
+
+	return p.refresh(ctx)
+}
+
+// refresh must be called while the lock is held, and releases the lock.
+// Only one caller sends the refresh request without holding the lock, and others wait for its result.
+func (p *RotatingTokenProvider) refresh(ctx context.Context) (string, error) {
+	call := p.refreshing
+	if call == nil {
+		call = &refreshCall{done: make(chan struct{})}
+		p.refreshing = call
+		current := *p.token
+		p.mutex.Unlock()
+
+		refreshed, err := p.requestRefresh(ctx, &current)
+
+		p.mutex.Lock()
+		if err == nil {
+			p.token = refreshed
+			call.token = refreshed.AccessToken
+		}
+		call.err = err
+		p.refreshing = nil
+		p.mutex.Unlock()
+		close(call.done)
+
+		return call.token, call.err
+	}
+	p.mutex.Unlock()
+
+	select {
+	case <-call.done:
+		return call.token, call.err
+
+	case <-ctx.Done():
+		return "", ctx.Err()
+
+	}
+}
+
+// requestRefresh calls oauth.v2.access method and persists the refreshed token.
+// The duration of the request is bounded by the timeout of the refresh client.
+func (p *RotatingTokenProvider) requestRefresh(ctx context.Context, current *RotatingToken) (*RotatingToken, error) {
+	// The context may carry this provider, which would make the refresh request wait for the refresh in progress.
+	// Clear it so the request is sent with client credentials only.
+	ctx = ContextWithToken(ctx, "")
+
+	req := NewOAuthV2Refresh(p.clientID, p.clientSecret, current.RefreshToken)
+	response := &OAuthV2AccessResponse{}
+	err := p.client.Post(ctx, "oauth.v2.access", req, response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh token: %w", err)
+	}
+	err = response.Err("oauth.v2.access")
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh token: %w", err)
+	}
+
+	refreshed := &RotatingToken{
+		AccessToken:  response.AccessToken,
+		RefreshToken: response.RefreshToken,
+	}
+	if refreshed.RefreshToken == "" {
+		refreshed.RefreshToken = current.RefreshToken
+	}
+	if response.ExpiresIn > 0 {
+		refreshed.ExpiresAt = p.now().Add(time.Duration(response.ExpiresIn) * time.Second)
+	}
+
+	if p.persist != nil {
+		// The previous refresh token is already spent, so the refreshed token is used even when persisting fails
+		copied := *refreshed
+		err := p.persist(ctx, &copied)
+		if err != nil && p.onPersistError != nil {
+			unsaved := *refreshed
+			p.onPersistError(ctx, &unsaved, err)
+		}
+	}
+
+	return refreshed, nil
+}
+
+func logPersistError(_ context.Context, _ *RotatingToken, err error) {
+	log.Printf("Failed to persist refreshed token: %s", err.Error())
+}
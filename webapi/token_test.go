@@ -0,0 +1,416 @@
+package webapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// refreshHandler serves oauth.v2.access by issuing xoxe.xoxb-1, xoxe.xoxb-2 and so on.
+func refreshHandler(t *testing.T, refreshed *int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get(AuthHeaderName) != "" {
+			t.Errorf("Token must not be sent: %s.", req.Header.Get(AuthHeaderName))
+		}
+
+		err := req.ParseForm()
+		if err != nil {
+			t.Errorf("Unexpected error is returned: %s.", err.Error())
+			return
+		}
+		if req.PostForm.Get("grant_type") != "refresh_token" || req.PostForm.Get("client_secret") != "secret" {
+			t.Errorf("Unexpected form is given: %#v.", req.PostForm)
+		}
+
+		// Make concurrent refreshes more likely to overlap
+		time.Sleep(10 * time.Millisecond)
+
+		n := atomic.AddInt32(refreshed, 1)
+		fmt.Fprintf(w, `{"ok": true, "access_token": "xoxe.xoxb-%d", "refresh_token": "xoxe-%d", "expires_in": 43200}`, n, n)
+	}
+}
+
+// newRefreshServer returns a Client that serves oauth.v2.access with refreshHandler.
+func newRefreshServer(t *testing.T, refreshed *int32) *Client {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/oauth.v2.access", refreshHandler(t, refreshed))
+
+	return &Client{
+		config:     NewConfig(),
+		httpClient: &http.Client{Transport: &localRoundTripper{mux: mux}},
+	}
+}
+
+func TestRotatingTokenProvider_Token(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		expiresAt time.Time
+		expected  string
+		refreshed int32
+	}{
+		{
+			expiresAt: now.Add(time.Hour),
+			expected:  "xoxe.xoxb-0",
+			refreshed: 0,
+		},
+		{
+			// Expires within the margin
+			expiresAt: now.Add(time.Minute),
+			expected:  "xoxe.xoxb-1",
+			refreshed: 1,
+		},
+		{
+			expiresAt: time.Time{},
+			expected:  "xoxe.xoxb-0",
+			refreshed: 0,
+		},
+	}
+
+	for i, tt := range tests {
+		var refreshed int32
+		var persisted *RotatingToken
+		token := &RotatingToken{
+			AccessToken:  "xoxe.xoxb-0",
+			RefreshToken: "xoxe-0",
+			ExpiresAt:    tt.expiresAt,
+		}
+		provider := NewRotatingTokenProvider("123.456", "secret", token,
+			WithRefreshClient(newRefreshServer(t, &refreshed)),
+			WithTokenPersister(func(_ context.Context, token *RotatingToken) error {
+				persisted = token
+				return nil
+			}))
+		provider.now = func() time.Time {
+			return now
+		}
+
+		given, err := provider.Token(context.TODO())
+		if err != nil {
+			t.Fatalf("Unexpected error is returned on test #%d: %s.", i, err.Error())
+		}
+
+		if given != tt.expected {
+			t.Errorf("Unexpected token is returned on test #%d: %s.", i, given)
+		}
+
+		if refreshed != tt.refreshed {
+			t.Errorf("Unexpected number of refreshes on test #%d: %d.", i, refreshed)
+		}
+
+		if tt.refreshed > 0 {
+			if persisted == nil {
+				t.Fatalf("Refreshed token is not persisted on test #%d.", i)
+			}
+			if persisted.AccessToken != tt.expected || persisted.RefreshToken != "xoxe-1" || !persisted.ExpiresAt.Equal(now.Add(12*time.Hour)) {
+				t.Errorf("Unexpected token is persisted on test #%d: %#v.", i, persisted)
+			}
+		}
+	}
+}
+
+func TestRotatingTokenProvider_Concurrency(t *testing.T) {
+	var refreshed int32
+	token := &RotatingToken{
+		AccessToken:  "xoxe.xoxb-0",
+		RefreshToken: "xoxe-0",
+		ExpiresAt:    time.Now().Add(-1 * time.Minute),
+	}
+	provider := NewRotatingTokenProvider("123.456", "secret", token, WithRefreshClient(newRefreshServer(t, &refreshed)))
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			given, err := provider.Token(context.TODO())
+			if err != nil || given != "xoxe.xoxb-1" {
+				t.Errorf("Unexpected token is returned: %s, %#v.", given, err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			given, err := provider.Refresh(context.TODO(), "xoxe.xoxb-0")
+			if err != nil || given != "xoxe.xoxb-1" {
+				t.Errorf("Unexpected token is returned: %s, %#v.", given, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if refreshed != 1 {
+		t.Errorf("Token must be refreshed only once: %d.", refreshed)
+	}
+}
+
+func TestRotatingTokenProvider_PersistError(t *testing.T) {
+	var refreshed int32
+	persistErr := errors.New("disk full")
+	token := &RotatingToken{
+		AccessToken:  "xoxe.xoxb-0",
+		RefreshToken: "xoxe-0",
+	}
+	var unsaved *RotatingToken
+	var handledErr error
+	provider := NewRotatingTokenProvider("123.456", "secret", token,
+		WithRefreshClient(newRefreshServer(t, &refreshed)),
+		WithTokenPersister(func(_ context.Context, _ *RotatingToken) error {
+			return persistErr
+		}),
+		WithPersistErrorHandler(func(_ context.Context, token *RotatingToken, err error) {
+			unsaved = token
+			handledErr = err
+		}))
+
+	// The refreshed token is returned since the previous refresh token is already spent
+	given, err := provider.Refresh(context.TODO(), "xoxe.xoxb-0")
+	if err != nil || given != "xoxe.xoxb-1" {
+		t.Errorf("Unexpected token is returned: %s, %#v.", given, err)
+	}
+
+	if handledErr != persistErr {
+		t.Errorf("Expected error is not handled: %#v.", handledErr)
+	}
+	if unsaved == nil || unsaved.RefreshToken != "xoxe-1" {
+		t.Errorf("Unsaved token is not given: %#v.", unsaved)
+	}
+
+	given, err = provider.Token(context.TODO())
+	if err != nil || given != "xoxe.xoxb-1" {
+		t.Errorf("Unexpected token is returned: %s, %#v.", given, err)
+	}
+}
+
+func TestRotatingTokenProvider_WaitRefresh(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/oauth.v2.access", func(w http.ResponseWriter, _ *http.Request) {
+		close(started)
+		<-release
+		w.Write([]byte(`{"ok": true, "access_token": "xoxe.xoxb-1", "refresh_token": "xoxe-1", "expires_in": 43200}`))
+	})
+	client := &Client{
+		config:     NewConfig(),
+		httpClient: &http.Client{Transport: &localRoundTripper{mux: mux}},
+	}
+	token := &RotatingToken{
+		AccessToken:  "xoxe.xoxb-0",
+		RefreshToken: "xoxe-0",
+		ExpiresAt:    time.Now(),
+	}
+	provider := NewRotatingTokenProvider("123.456", "secret", token, WithRefreshClient(client))
+
+	refreshed := make(chan string, 1)
+	go func() {
+		given, _ := provider.Token(context.TODO())
+		refreshed <- given
+	}()
+	<-started
+
+	// Another caller stops waiting for the refresh in progress when its context is done
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	_, err := provider.Token(ctx)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected error is not returned: %#v.", err)
+	}
+
+	close(release)
+	select {
+	case given := <-refreshed:
+		if given != "xoxe.xoxb-1" {
+			t.Errorf("Unexpected token is returned: %s.", given)
+		}
+
+	case <-time.NewTimer(1 * time.Second).C:
+		t.Fatal("Refresh is not finished.")
+
+	}
+}
+
+func TestClient_TokenExpired(t *testing.T) {
+	tests := []struct {
+		expireAll bool
+		requested int
+		err       bool
+	}{
+		{
+			expireAll: false,
+			requested: 2,
+			err:       false,
+		},
+		{
+			// Retried only once
+			expireAll: true,
+			requested: 2,
+			err:       true,
+		},
+	}
+
+	for i, tt := range tests {
+		var refreshed int32
+		requested := 0
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/chat.postMessage", func(w http.ResponseWriter, req *http.Request) {
+			requested++
+			if req.Header.Get(AuthHeaderName) == "Bearer xoxe.xoxb-0" || tt.expireAll {
+				w.Write([]byte(`{"ok": false, "error": "token_expired"}`))
+				return
+			}
+			w.Write([]byte(`{"ok": true}`))
+		})
+
+		token := &RotatingToken{
+			AccessToken:  "xoxe.xoxb-0",
+			RefreshToken: "xoxe-0",
+		}
+		provider := NewRotatingTokenProvider("123.456", "secret", token, WithRefreshClient(newRefreshServer(t, &refreshed)))
+		client := NewClient(NewConfig(), WithTokenProvider(provider), WithHTTPClient(&http.Client{Transport: &localRoundTripper{mux: mux}}))
+
+		response := &APIResponse{}
+		err := client.Post(context.TODO(), "chat.postMessage", NewPostMessage("C123", "Hello"), response)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned on test #%d: %s.", i, err.Error())
+		}
+
+		if requested != tt.requested {
+			t.Errorf("Unexpected number of requests on test #%d: %d.", i, requested)
+		}
+
+		if refreshed != 1 {
+			t.Errorf("Unexpected number of refreshes on test #%d: %d.", i, refreshed)
+		}
+
+		if (response.Err("chat.postMessage") != nil) != tt.err {
+			t.Errorf("Unexpected response is returned on test #%d: %#v.", i, response)
+		}
+	}
+}
+
+func TestContextWithToken(t *testing.T) {
+	var refreshed int32
+	provider := NewRotatingTokenProvider("123.456", "secret", &RotatingToken{AccessToken: "xoxe.xoxb-0", RefreshToken: "xoxe-0"},
+		WithRefreshClient(newRefreshServer(t, &refreshed)))
+
+	tests := []struct {
+		ctx      context.Context
+		options  []ClientOption
+		expected string
+	}{
+		{
+			ctx:      context.TODO(),
+			expected: "Bearer config",
+		},
+		{
+			ctx:      ContextWithToken(context.TODO(), "xoxb-team"),
+			expected: "Bearer xoxb-team",
+		},
+		{
+			// The token in the context takes precedence over the client's provider
+			ctx:      ContextWithToken(context.TODO(), "xoxb-team"),
+			options:  []ClientOption{WithTokenProvider(provider)},
+			expected: "Bearer xoxb-team",
+		},
+		{
+			ctx:      ContextWithTokenProvider(context.TODO(), provider),
+			expected: "Bearer xoxe.xoxb-0",
+		},
+	}
+
+	for i, tt := range tests {
+		var given string
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/auth.test", func(w http.ResponseWriter, req *http.Request) {
+			given = req.Header.Get(AuthHeaderName)
+			w.Write([]byte(`{"ok": true}`))
+		})
+
+		config := NewConfig()
+		config.Token = "config"
+		options := append([]ClientOption{WithHTTPClient(&http.Client{Transport: &localRoundTripper{mux: mux}})}, tt.options...)
+		client := NewClient(config, options...)
+
+		err := client.Get(tt.ctx, "auth.test", nil, &APIResponse{})
+		if err != nil {
+			t.Fatalf("Unexpected error is returned on test #%d: %s.", i, err.Error())
+		}
+
+		if given != tt.expected {
+			t.Errorf("Unexpected token is sent on test #%d: %s.", i, given)
+		}
+	}
+}
+
+func TestContextWithTokenProvider_Refresh(t *testing.T) {
+	tests := []struct {
+		expiresAt time.Time
+		rejected  bool
+	}{
+		{
+			// Refreshed before the request
+			expiresAt: time.Now().Add(time.Minute),
+			rejected:  false,
+		},
+		{
+			// Refreshed when Slack rejects the token
+			expiresAt: time.Time{},
+			rejected:  true,
+		},
+	}
+
+	for i, tt := range tests {
+		var refreshed int32
+		var given string
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/oauth.v2.access", refreshHandler(t, &refreshed))
+		mux.HandleFunc("/api/auth.test", func(w http.ResponseWriter, req *http.Request) {
+			given = req.Header.Get(AuthHeaderName)
+			if tt.rejected && given == "Bearer xoxe.xoxb-0" {
+				w.Write([]byte(`{"ok": false, "error": "token_expired"}`))
+				return
+			}
+			w.Write([]byte(`{"ok": true}`))
+		})
+
+		// The same Client serves the refresh request as well as the request with the provider in the context
+		config := NewConfig()
+		config.Token = "config"
+		client := NewClient(config, WithHTTPClient(&http.Client{Transport: &localRoundTripper{mux: mux}}))
+		token := &RotatingToken{
+			AccessToken:  "xoxe.xoxb-0",
+			RefreshToken: "xoxe-0",
+			ExpiresAt:    tt.expiresAt,
+		}
+		provider := NewRotatingTokenProvider("123.456", "secret", token, WithRefreshClient(client))
+
+		errs := make(chan error, 1)
+		go func() {
+			errs <- client.Get(ContextWithTokenProvider(context.TODO(), provider), "auth.test", nil, &APIResponse{})
+		}()
+
+		select {
+		case err := <-errs:
+			if err != nil {
+				t.Fatalf("Unexpected error is returned on test #%d: %s.", i, err.Error())
+			}
+
+		case <-time.NewTimer(1 * time.Second).C:
+			t.Fatalf("Request is not finished on test #%d.", i)
+
+		}
+
+		if refreshed != 1 {
+			t.Errorf("Unexpected number of refreshes on test #%d: %d.", i, refreshed)
+		}
+
+		if given != "Bearer xoxe.xoxb-1" {
+			t.Errorf("Unexpected token is sent on test #%d: %s.", i, given)
+		}
+	}
+}
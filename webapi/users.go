@@ -0,0 +1,192 @@
+package webapi
+
+import (
+	"github.com/oklahomer/golack/v2/event"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// UserResponse is a response of methods that return a single user such as users.info and users.lookupByEmail.
+type UserResponse struct {
+	APIResponse
+	User *User `json:"user"`
+}
+
+// UserProfileResponse is a response of users.profile.get and users.profile.set methods.
+type UserProfileResponse struct {
+	APIResponse
+	Profile *UserProfile `json:"profile"`
+}
+
+// Presence is a response of users.getPresence method.
+// Online, AutoAway, ManualAway, ConnectionCount and LastActivity are only given for the authenticated user.
+// See https://api.slack.com/methods/users.getPresence
+type Presence struct {
+	APIResponse
+	Presence        string           `json:"presence"`
+	Online          bool             `json:"online"`
+	AutoAway        bool             `json:"auto_away"`
+	ManualAway      bool             `json:"manual_away"`
+	ConnectionCount int              `json:"connection_count"`
+	LastActivity    *event.TimeStamp `json:"last_activity"`
+}
+
+// PresenceType defines the presence values for users.setPresence method.
+// See https://api.slack.com/methods/users.setPresence
+type PresenceType string
+
+const (
+	PresenceAuto PresenceType = "auto"
+	PresenceAway PresenceType = "away"
+)
+
+// String returns a stringified form of PresenceType
+func (presence PresenceType) String() string {
+	return string(presence)
+}
+
+// SetPresence is a payload to be sent with users.setPresence method.
+// See https://api.slack.com/methods/users.setPresence
+type SetPresence struct {
+	Presence PresenceType `json:"presence"`
+}
+
+// UsersInfoRequest is a payload to be sent with users.info method.
+// See https://api.slack.com/methods/users.info
+type UsersInfoRequest struct {
+	UserID        event.UserID
+	IncludeLocale bool
+}
+
+var _ URLValuer = (*UsersInfoRequest)(nil)
+
+// NewUsersInfoRequest creates UsersInfoRequest with the given user.
+func NewUsersInfoRequest(userID event.UserID) *UsersInfoRequest {
+	return &UsersInfoRequest{
+		UserID: userID,
+	}
+}
+
+// WithIncludeLocale sets optional boolean value to include the locale of the user.
+func (req *UsersInfoRequest) WithIncludeLocale(flg bool) *UsersInfoRequest {
+	req.IncludeLocale = flg
+	return req
+}
+
+// ToURLValues returns query parameters of the request.
+func (req *UsersInfoRequest) ToURLValues() url.Values {
+	values := url.Values{}
+	values.Set("user", req.UserID.String())
+	if req.IncludeLocale {
+		values.Set("include_locale", "true")
+	}
+	return values
+}
+
+// UsersListRequest is a payload to be sent with users.list method.
+// To iterate over all users, use Client.IterateUsers.
+// See https://api.slack.com/methods/users.list
+type UsersListRequest struct {
+	Cursor        string
+	IncludeLocale bool
+	Limit         int
+	TeamID        event.TeamID
+}
+
+var _ URLValuer = (*UsersListRequest)(nil)
+
+// NewUsersListRequest creates UsersListRequest to fetch the first page.
+func NewUsersListRequest() *UsersListRequest {
+	return &UsersListRequest{}
+}
+
+// WithCursor sets the cursor given by the previous response to fetch the next page.
+func (req *UsersListRequest) WithCursor(cursor string) *UsersListRequest {
+	req.Cursor = cursor
+	return req
+}
+
+// WithIncludeLocale sets optional boolean value to include the locale of each user.
+func (req *UsersListRequest) WithIncludeLocale(flg bool) *UsersListRequest {
+	req.IncludeLocale = flg
+	return req
+}
+
+// WithLimit sets the maximum number of users to return.
+func (req *UsersListRequest) WithLimit(limit int) *UsersListRequest {
+	req.Limit = limit
+	return req
+}
+
+// WithTeamID sets the team to list users in when the token belongs to an Enterprise Grid organization.
+func (req *UsersListRequest) WithTeamID(teamID event.TeamID) *UsersListRequest {
+	req.TeamID = teamID
+	return req
+}
+
+// ToURLValues returns query parameters of the request.
+func (req *UsersListRequest) ToURLValues() url.Values {
+	values := url.Values{}
+	if req.Cursor != "" {
+		values.Set("cursor", req.Cursor)
+	}
+	if req.IncludeLocale {
+		values.Set("include_locale", "true")
+	}
+	if req.Limit > 0 {
+		values.Set("limit", strconv.Itoa(req.Limit))
+	}
+	if req.TeamID != "" {
+		values.Set("team_id", req.TeamID.String())
+	}
+	return values
+}
+
+// SetUserProfile is a payload to be sent with users.profile.set method.
+// Only the fields set with WithField are updated, so other fields of the profile remain untouched.
+// See https://api.slack.com/methods/users.profile.set
+type SetUserProfile struct {
+	// UserID is the user to update. This requires an admin token when the user is not the authenticated user.
+	UserID  event.UserID           `json:"user,omitempty"`
+	Profile map[string]interface{} `json:"profile"`
+}
+
+// WithUserID sets the user to update.
+func (profile *SetUserProfile) WithUserID(userID event.UserID) *SetUserProfile {
+	profile.UserID = userID
+	return profile
+}
+
+// WithField sets a profile field to update such as "display_name" and "title".
+func (profile *SetUserProfile) WithField(name string, value interface{}) *SetUserProfile {
+	profile.Profile[name] = value
+	return profile
+}
+
+// NewSetUserProfile creates SetUserProfile without any field to update.
+func NewSetUserProfile() *SetUserProfile {
+	return &SetUserProfile{
+		Profile: map[string]interface{}{},
+	}
+}
+
+// NewSetUserStatus creates SetUserProfile to set the custom status with the given text and emoji such as ":pager:".
+// The status is cleared at the given expiration. Give zero time.Time for a status that does not expire.
+// See https://api.slack.com/docs/presence-and-status#custom_status
+func NewSetUserStatus(text string, emoji string, expiration time.Time) *SetUserProfile {
+	var expirationUnix int64
+	if !expiration.IsZero() {
+		expirationUnix = expiration.Unix()
+	}
+
+	return NewSetUserProfile().
+		WithField("status_text", text).
+		WithField("status_emoji", emoji).
+		WithField("status_expiration", expirationUnix)
+}
+
+// NewClearUserStatus creates SetUserProfile to clear the custom status.
+func NewClearUserStatus() *SetUserProfile {
+	return NewSetUserStatus("", "", time.Time{})
+}
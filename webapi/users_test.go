@@ -0,0 +1,84 @@
+package webapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestPresenceType_String(t *testing.T) {
+	if PresenceAway.String() != "away" {
+		t.Errorf("Unexpected value is returned: %s.", PresenceAway.String())
+	}
+}
+
+func TestUsersInfoRequest_ToURLValues(t *testing.T) {
+	values := NewUsersInfoRequest("U123").WithIncludeLocale(true).ToURLValues()
+
+	if values.Get("user") != "U123" || values.Get("include_locale") != "true" {
+		t.Errorf("Expected values are not set: %s.", values.Encode())
+	}
+}
+
+func TestUsersListRequest_ToURLValues(t *testing.T) {
+	values := NewUsersListRequest().ToURLValues()
+	if len(values) != 0 {
+		t.Errorf("Unset values are given: %s.", values.Encode())
+	}
+
+	values = NewUsersListRequest().WithCursor("abc").WithIncludeLocale(true).WithLimit(100).WithTeamID("T123").ToURLValues()
+	if values.Get("cursor") != "abc" || values.Get("include_locale") != "true" || values.Get("limit") != "100" || values.Get("team_id") != "T123" {
+		t.Errorf("Expected values are not set: %s.", values.Encode())
+	}
+}
+
+func TestNewSetUserStatus(t *testing.T) {
+	t.Run("with expiration", func(t *testing.T) {
+		profile := NewSetUserStatus("On call", ":pager:", time.Unix(1532627506, 0)).WithUserID("U123")
+
+		b, err := json.Marshal(profile)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned: %s.", err.Error())
+		}
+
+		expected := `{"user":"U123","profile":{"status_emoji":":pager:","status_expiration":1532627506,"status_text":"On call"}}`
+		if string(b) != expected {
+			t.Errorf("Unexpected payload is built: %s.", string(b))
+		}
+	})
+
+	t.Run("without expiration", func(t *testing.T) {
+		profile := NewSetUserStatus("Vacationing", ":palm_tree:", time.Time{})
+
+		if profile.Profile["status_expiration"] != int64(0) {
+			t.Errorf("Unexpected expiration is set: %#v.", profile.Profile["status_expiration"])
+		}
+	})
+}
+
+func TestNewClearUserStatus(t *testing.T) {
+	b, err := json.Marshal(NewClearUserStatus())
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+
+	// Empty values must be sent to clear the status
+	expected := `{"profile":{"status_emoji":"","status_expiration":0,"status_text":""}}`
+	if string(b) != expected {
+		t.Errorf("Unexpected payload is built: %s.", string(b))
+	}
+}
+
+func TestPresence_UnmarshalJSON(t *testing.T) {
+	input := []byte(`{"ok": true, "presence": "active", "online": true, "auto_away": false, "manual_away": false, "connection_count": 1, "last_activity": 1419027078}`)
+
+	presence := &Presence{}
+	err := json.Unmarshal(input, presence)
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+
+	if presence.Presence != "active" || !presence.Online || presence.LastActivity.Time.Unix() != 1419027078 {
+		t.Errorf("Response is not decoded: %#v.", presence)
+	}
+}
@@ -0,0 +1,184 @@
+package webapi
+
+import (
+	"github.com/oklahomer/golack/v2/event"
+)
+
+// ViewDefinition is an outgoing view object to be sent with views.* methods.
+// Use NewModalView or NewHomeView to build one.
+// See https://api.slack.com/reference/surfaces/views
+type ViewDefinition struct {
+	Type            string                       `json:"type"`
+	Title           *event.TextCompositionObject `json:"title,omitempty"`
+	Blocks          []event.Block                `json:"blocks"`
+	Close           *event.TextCompositionObject `json:"close,omitempty"`
+	Submit          *event.TextCompositionObject `json:"submit,omitempty"`
+	SubmitDisabled  bool                         `json:"submit_disabled,omitempty"`
+	PrivateMetadata string                       `json:"private_metadata,omitempty"`
+	CallbackID      string                       `json:"callback_id,omitempty"`
+	ClearOnClose    bool                         `json:"clear_on_close,omitempty"`
+	NotifyOnClose   bool                         `json:"notify_on_close,omitempty"`
+	ExternalID      string                       `json:"external_id,omitempty"`
+}
+
+// WithClose sets the text of the close button of the modal.
+func (view *ViewDefinition) WithClose(text *event.TextCompositionObject) *ViewDefinition {
+	view.Close = text
+	return view
+}
+
+// WithSubmit sets the text of the submit button of the modal.
+// This is required when the modal contains any input block.
+func (view *ViewDefinition) WithSubmit(text *event.TextCompositionObject) *ViewDefinition {
+	view.Submit = text
+	return view
+}
+
+// WithSubmitDisabled sets optional boolean value to disable the submit button until the user completes one or more inputs.
+func (view *ViewDefinition) WithSubmitDisabled(flg bool) *ViewDefinition {
+	view.SubmitDisabled = flg
+	return view
+}
+
+// WithPrivateMetadata sets a string that is sent back with view_submission and block_actions payloads.
+func (view *ViewDefinition) WithPrivateMetadata(metadata string) *ViewDefinition {
+	view.PrivateMetadata = metadata
+	return view
+}
+
+// WithCallbackID sets an identifier to recognize interactions and submissions of the view.
+func (view *ViewDefinition) WithCallbackID(callbackID string) *ViewDefinition {
+	view.CallbackID = callbackID
+	return view
+}
+
+// WithClearOnClose sets optional boolean value to close all views in the modal stack when the modal is closed.
+func (view *ViewDefinition) WithClearOnClose(flg bool) *ViewDefinition {
+	view.ClearOnClose = flg
+	return view
+}
+
+// WithNotifyOnClose sets optional boolean value to receive view_closed event when the modal is closed.
+func (view *ViewDefinition) WithNotifyOnClose(flg bool) *ViewDefinition {
+	view.NotifyOnClose = flg
+	return view
+}
+
+// WithExternalID sets a unique identifier of the view in the workspace so the view can be updated with it.
+func (view *ViewDefinition) WithExternalID(externalID string) *ViewDefinition {
+	view.ExternalID = externalID
+	return view
+}
+
+// NewModalView creates ViewDefinition of a modal with the given title and blocks.
+// The title must be a plain_text object of 24 characters or less.
+// See https://api.slack.com/reference/surfaces/views#modal
+func NewModalView(title *event.TextCompositionObject, blocks []event.Block) *ViewDefinition {
+	return &ViewDefinition{
+		Type:   "modal",
+		Title:  title,
+		Blocks: blocks,
+	}
+}
+
+// NewHomeView creates ViewDefinition of a Home tab with the given blocks.
+// See https://api.slack.com/reference/surfaces/views#home
+func NewHomeView(blocks []event.Block) *ViewDefinition {
+	return &ViewDefinition{
+		Type:   "home",
+		Blocks: blocks,
+	}
+}
+
+// ViewResponse is a response of views.open, views.push, views.update and views.publish methods.
+// The Hash of the returned View can be passed to the next views.update or views.publish call.
+type ViewResponse struct {
+	APIResponse
+	View *event.View `json:"view"`
+}
+
+// OpenView is a payload to be sent with views.open method.
+// See https://api.slack.com/methods/views.open
+type OpenView struct {
+	TriggerID string          `json:"trigger_id"`
+	View      *ViewDefinition `json:"view"`
+}
+
+// NewOpenView creates OpenView instance to open the given modal with the trigger_id given by an interaction.
+func NewOpenView(triggerID string, view *ViewDefinition) *OpenView {
+	return &OpenView{
+		TriggerID: triggerID,
+		View:      view,
+	}
+}
+
+// PushView is a payload to be sent with views.push method.
+// See https://api.slack.com/methods/views.push
+type PushView struct {
+	TriggerID string          `json:"trigger_id"`
+	View      *ViewDefinition `json:"view"`
+}
+
+// NewPushView creates PushView instance to push the given modal onto the stack of the currently opened modal.
+func NewPushView(triggerID string, view *ViewDefinition) *PushView {
+	return &PushView{
+		TriggerID: triggerID,
+		View:      view,
+	}
+}
+
+// UpdateView is a payload to be sent with views.update method.
+// Either ViewID or ExternalID is required.
+// See https://api.slack.com/methods/views.update
+type UpdateView struct {
+	View       *ViewDefinition `json:"view"`
+	ViewID     event.ViewID    `json:"view_id,omitempty"`
+	ExternalID string          `json:"external_id,omitempty"`
+	Hash       string          `json:"hash,omitempty"`
+}
+
+// WithHash sets the hash of the view that is about to be updated.
+// The request fails with hash_conflict error when the view is already updated by another request.
+func (update *UpdateView) WithHash(hash string) *UpdateView {
+	update.Hash = hash
+	return update
+}
+
+// NewUpdateView creates UpdateView instance to update the view with the given ID.
+func NewUpdateView(viewID event.ViewID, view *ViewDefinition) *UpdateView {
+	return &UpdateView{
+		View:   view,
+		ViewID: viewID,
+	}
+}
+
+// NewUpdateViewByExternalID creates UpdateView instance to update the view with the given external ID.
+func NewUpdateViewByExternalID(externalID string, view *ViewDefinition) *UpdateView {
+	return &UpdateView{
+		View:       view,
+		ExternalID: externalID,
+	}
+}
+
+// PublishView is a payload to be sent with views.publish method.
+// See https://api.slack.com/methods/views.publish
+type PublishView struct {
+	UserID event.UserID    `json:"user_id"`
+	View   *ViewDefinition `json:"view"`
+	Hash   string          `json:"hash,omitempty"`
+}
+
+// WithHash sets the hash of the Home tab that is about to be updated.
+// The request fails with hash_conflict error when the Home tab is already updated by another request.
+func (publish *PublishView) WithHash(hash string) *PublishView {
+	publish.Hash = hash
+	return publish
+}
+
+// NewPublishView creates PublishView instance to publish the given Home tab for the given user.
+func NewPublishView(userID event.UserID, view *ViewDefinition) *PublishView {
+	return &PublishView{
+		UserID: userID,
+		View:   view,
+	}
+}
@@ -0,0 +1,106 @@
+package webapi
+
+import (
+	"encoding/json"
+	"github.com/oklahomer/golack/v2/event"
+	"testing"
+)
+
+func TestNewModalView(t *testing.T) {
+	view := NewModalView(event.NewPlainTextCompositionObject("Title"), []event.Block{event.NewDividerBlock()}).
+		WithSubmit(event.NewPlainTextCompositionObject("Submit")).
+		WithClose(event.NewPlainTextCompositionObject("Cancel")).
+		WithCallbackID("callback").
+		WithPrivateMetadata("metadata").
+		WithNotifyOnClose(true)
+
+	b, err := json.Marshal(NewOpenView("trigger", view))
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+
+	expected := `{"trigger_id":"trigger","view":{"type":"modal","title":{"type":"plain_text","text":"Title"},"blocks":[{"type":"divider"}],` +
+		`"close":{"type":"plain_text","text":"Cancel"},"submit":{"type":"plain_text","text":"Submit"},` +
+		`"private_metadata":"metadata","callback_id":"callback","notify_on_close":true}}`
+	if string(b) != expected {
+		t.Errorf("Unexpected payload is built: %s.", string(b))
+	}
+}
+
+func TestNewHomeView(t *testing.T) {
+	view := NewHomeView([]event.Block{event.NewDividerBlock()}).WithExternalID("home")
+
+	b, err := json.Marshal(NewPublishView("U123", view).WithHash("hash"))
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+
+	expected := `{"user_id":"U123","view":{"type":"home","blocks":[{"type":"divider"}],"external_id":"home"},"hash":"hash"}`
+	if string(b) != expected {
+		t.Errorf("Unexpected payload is built: %s.", string(b))
+	}
+}
+
+func TestNewUpdateView(t *testing.T) {
+	view := NewModalView(event.NewPlainTextCompositionObject("Title"), []event.Block{})
+
+	tests := []struct {
+		update   *UpdateView
+		expected string
+	}{
+		{
+			update:   NewUpdateView("V123", view).WithHash("hash"),
+			expected: `{"view":{"type":"modal","title":{"type":"plain_text","text":"Title"},"blocks":[]},"view_id":"V123","hash":"hash"}`,
+		},
+		{
+			update:   NewUpdateViewByExternalID("external", view),
+			expected: `{"view":{"type":"modal","title":{"type":"plain_text","text":"Title"},"blocks":[]},"external_id":"external"}`,
+		},
+	}
+
+	for i, tt := range tests {
+		b, err := json.Marshal(tt.update)
+		if err != nil {
+			t.Fatalf("Unexpected error is returned on test #%d: %s.", i, err.Error())
+		}
+
+		if string(b) != tt.expected {
+			t.Errorf("Unexpected payload is built on test #%d: %s.", i, string(b))
+		}
+	}
+}
+
+func TestViewResponse_UnmarshalJSON(t *testing.T) {
+	input := []byte(`{
+		"ok": true,
+		"view": {
+			"id": "V123",
+			"type": "modal",
+			"title": {"type": "plain_text", "text": "Title"},
+			"submit": {"type": "plain_text", "text": "Submit"},
+			"close": {"type": "plain_text", "text": "Cancel"},
+			"blocks": [{"type": "divider"}],
+			"hash": "156772938.1827394",
+			"previous_view_id": "V000"
+		}
+	}`)
+
+	response := &ViewResponse{}
+	err := json.Unmarshal(input, response)
+	if err != nil {
+		t.Fatalf("Unexpected error is returned: %s.", err.Error())
+	}
+
+	view := response.View
+	if view.ID != "V123" || view.Hash != "156772938.1827394" || view.PreviousViewID != "V000" {
+		t.Errorf("View is not decoded: %#v.", view)
+	}
+
+	if view.Title.Text != "Title" || view.Submit.Text != "Submit" || view.Close.Text != "Cancel" {
+		t.Errorf("Texts are not decoded: %#v.", view)
+	}
+
+	if len(view.Blocks) != 1 || view.Blocks[0].BlockType() != "divider" {
+		t.Errorf("Blocks are not decoded: %#v.", view.Blocks)
+	}
+}